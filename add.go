@@ -1,6 +1,7 @@
 package dino
 
 import (
+	"errors"
 	"reflect"
 	"strings"
 )
@@ -18,31 +19,14 @@ func Add[T any, TImpl any](c *Container) error {
 func AddNamed[T any, TImpl any](c *Container, name string) error {
 	t, tImpl := getTypes[T, TImpl]()
 
-	if tImpl.Kind() != reflect.Struct {
-		return ImplNotStructError{ty: tImpl}
-	}
-
-	switch t.Kind() {
-	case reflect.Interface:
-		if !reflect.PointerTo(tImpl).Implements(t) {
-			return NotImplementsError{ifTy: t, actualImplTy: tImpl}
-		}
-	case reflect.Pointer:
-		if t.Elem().Kind() != reflect.Struct {
-			return InvalidServiceTypeError{ty: t}
-		} else if t.Elem() != tImpl {
-			return BadPointerError{pointerTy: t, structTy: tImpl}
-		}
-	default:
-		return InvalidServiceTypeError{ty: t}
+	if err := checkImplType(t, tImpl); err != nil {
+		return err
 	}
 
-	c.store(t, name, &singletonBinding{
+	return c.store(t, name, &singletonBinding{
 		implType: tImpl,
-		built:    false,
+		byValue:  t.Kind() == reflect.Struct,
 	})
-
-	return nil
 }
 
 // AddTransient registers a service of type T as a transient in the provided container.
@@ -60,6 +44,19 @@ func AddTransient[T any, TImpl any](c *Container) error {
 func AddTransientNamed[T any, TImpl any](c *Container, name string) error {
 	t, tImpl := getTypes[T, TImpl]()
 
+	if err := checkImplType(t, tImpl); err != nil {
+		return err
+	}
+
+	return c.store(t, name, &transientBinding{
+		implType: tImpl,
+		byValue:  t.Kind() == reflect.Struct,
+	})
+}
+
+// checkImplType checks whether Dino can construct a service of type t
+// by creating a struct of type tImpl and injecting its fields.
+func checkImplType(t reflect.Type, tImpl reflect.Type) error {
 	if tImpl.Kind() != reflect.Struct {
 		return ImplNotStructError{ty: tImpl}
 	}
@@ -75,13 +72,70 @@ func AddTransientNamed[T any, TImpl any](c *Container, name string) error {
 		} else if t.Elem() != tImpl {
 			return BadPointerError{pointerTy: t, structTy: tImpl}
 		}
+	case reflect.Struct:
+		// Struct services are provided by value, so the types must be the same
+		if t != tImpl {
+			return NotAssignableError{svcTy: t, implTy: tImpl}
+		}
 	default:
 		return InvalidServiceTypeError{ty: t}
 	}
 
-	c.store(t, name, &transientBinding{
+	return nil
+}
+
+// AsOption describes an additional type (and namespace) that a service should be available as.
+type AsOption struct {
+	ty   reflect.Type
+	name string
+}
+
+// As makes a service available as type T in a global namespace.
+func As[T any]() AsOption {
+	return AsNamed[T]("")
+}
+
+// AsNamed makes a service available as type T under a provided namespace.
+func AsNamed[T any](name string) AsOption {
+	return AsOption{ty: getType[T](), name: name}
+}
+
+// AddAs registers a service of type *TImpl as a singleton in the provided container
+// and makes the same instance available as every type described by the options.
+//
+// In this case, Dino will itself create a single object of type TImpl,
+// shared by all of the registrations.
+func AddAs[TImpl any](c *Container, as ...AsOption) error {
+	tImpl := getType[TImpl]()
+	ptrTy := reflect.PointerTo(tImpl)
+
+	if err := checkImplType(ptrTy, tImpl); err != nil {
+		return err
+	}
+
+	// Check all the types first, so that nothing gets registered on error
+	for _, opt := range as {
+		if opt.ty.Kind() == reflect.Struct {
+			// A struct value cannot share its instance with the pointer
+			return InvalidServiceTypeError{ty: opt.ty}
+		}
+		if err := checkImplType(opt.ty, tImpl); err != nil {
+			return err
+		}
+	}
+
+	binding := &singletonBinding{
 		implType: tImpl,
-	})
+	}
+
+	if err := c.store(ptrTy, "", binding); err != nil {
+		return err
+	}
+	for _, opt := range as {
+		if err := c.store(opt.ty, opt.name, binding); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -96,27 +150,117 @@ func AddInstance[T any, TImpl any](c *Container, instance TImpl) error {
 // in the container under a provided namespace.
 func AddInstanceNamed[T any, TImpl any](c *Container, name string, instance TImpl) error {
 	t, tImpl := getTypes[T, TImpl]()
+	binding, err := newInstanceBinding(t, tImpl, reflect.ValueOf(instance))
+	if err != nil {
+		return err
+	}
+
+	return c.store(t, name, binding)
+}
 
+// newInstanceBinding checks whether an object of type tImpl can be provided as a service of type t
+// and creates a binding providing it.
+func newInstanceBinding(t reflect.Type, tImpl reflect.Type, instanceValue reflect.Value) (*instanceBinding, error) {
 	switch t.Kind() {
 	case reflect.Interface:
 		if !reflect.PointerTo(tImpl).Implements(t) && !tImpl.Implements(t) {
-			return NotImplementsError{ifTy: t, actualImplTy: tImpl}
+			return nil, NotImplementsError{ifTy: t, actualImplTy: tImpl}
 		}
 	case reflect.Pointer:
-		if t.Elem().Kind() != reflect.Struct {
-			return InvalidServiceTypeError{ty: t}
-		} else if t != tImpl && t.Elem() != tImpl {
-			return BadPointerError{pointerTy: t, structTy: tImpl}
+		if t.Elem().Kind() == reflect.Struct {
+			if t != tImpl && t.Elem() != tImpl {
+				return nil, BadPointerError{pointerTy: t, structTy: tImpl}
+			}
+			break
 		}
+		fallthrough
 	default:
+		if !isValidServiceType(t) {
+			return nil, InvalidServiceTypeError{ty: t}
+		} else if !isAssignableService(t, tImpl) {
+			return nil, NotAssignableError{svcTy: t, implTy: tImpl}
+		}
+
+		// Store the value as T, so that named types (eg. type Port int)
+		// do not get provided as their underlying type.
+		instanceValue = instanceValue.Convert(t)
+	}
+
+	return &instanceBinding{instance: instanceValue}, nil
+}
+
+// AddFactory registers a service of type T as a singleton in the provided container.
+//
+// In this case, Dino will call the provided factory once to create the object
+// in a global namespace.
+func AddFactory[T any](c *Container, factory func(c *Container) (T, error)) error {
+	return AddFactoryNamed(c, "", factory)
+}
+
+// AddFactoryNamed registers a service of type T as a singleton in the provided container.
+//
+// In this case, Dino will call the provided factory once to create the object
+// under a provided namespace.
+func AddFactoryNamed[T any](c *Container, name string, factory func(c *Container) (T, error)) error {
+	t := getType[T]()
+	if !isValidServiceType(t) {
 		return InvalidServiceTypeError{ty: t}
 	}
 
-	c.store(t, name, &instanceBinding{
-		instance: reflect.ValueOf(instance),
+	return c.store(t, name, &singletonBinding{
+		implType: t,
+		factory:  wrapFactory(factory),
 	})
+}
 
-	return nil
+// AddTransientFactory registers a service of type T as a transient in the provided container.
+//
+// In this case, Dino will call the provided factory each time the object
+// gets requested from a global namespace.
+func AddTransientFactory[T any](c *Container, factory func(c *Container) (T, error)) error {
+	return AddTransientFactoryNamed(c, "", factory)
+}
+
+// AddTransientFactoryNamed registers a service of type T as a transient in the provided container.
+//
+// In this case, Dino will call the provided factory each time the object
+// gets requested from a provided namespace.
+func AddTransientFactoryNamed[T any](c *Container, name string, factory func(c *Container) (T, error)) error {
+	t := getType[T]()
+	if !isValidServiceType(t) {
+		return InvalidServiceTypeError{ty: t}
+	}
+
+	return c.store(t, name, &transientBinding{
+		implType: t,
+		factory:  wrapFactory(factory),
+	})
+}
+
+// isAssignableService checks whether an object of type tImpl
+// can be registered as a service of a non-interface type t.
+//
+// Apart from regular assignability, it also allows predeclared types
+// to be used as named types of the same kind, eg. an untyped constant 8080 for type Port int.
+func isAssignableService(t reflect.Type, tImpl reflect.Type) bool {
+	if tImpl.AssignableTo(t) {
+		return true
+	}
+
+	return tImpl.PkgPath() == "" && tImpl.Kind() == t.Kind() && tImpl.ConvertibleTo(t)
+}
+
+// isValidServiceType checks whether values of a provided type
+// can be registered in a container and injected into fields.
+func isValidServiceType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Invalid, reflect.UnsafePointer:
+		return false
+	case reflect.Pointer:
+		return t.Elem().Kind() != reflect.Interface
+	default:
+		return true
+	}
 }
 
 // InvalidServiceTypeError occurs when a user wants to register a type,
@@ -125,15 +269,48 @@ type InvalidServiceTypeError struct {
 	ty reflect.Type
 }
 
+// Type returns the type the user wanted to register.
+func (e InvalidServiceTypeError) Type() reflect.Type {
+	return e.ty
+}
+
 func (e InvalidServiceTypeError) Error() string {
 	var b strings.Builder
 	b.WriteString("type ")
 	b.WriteString(e.ty.String())
-	b.WriteString(" is not a valid service type ")
-	b.WriteString("(must be interface or pointer to struct)")
+	b.WriteString(" is not a valid service type")
+	return b.String()
+}
+
+// NotAssignableError occurs when a user wants to register an object as a service,
+// but its type cannot be used as a value of the service type.
+type NotAssignableError struct {
+	svcTy  reflect.Type
+	implTy reflect.Type
+}
+
+// ServiceType returns the type of the service the user wanted to register.
+func (e NotAssignableError) ServiceType() reflect.Type {
+	return e.svcTy
+}
+
+// ImplType returns the type of the object the user wanted to register.
+func (e NotAssignableError) ImplType() reflect.Type {
+	return e.implTy
+}
+
+func (e NotAssignableError) Error() string {
+	var b strings.Builder
+	b.WriteString("value of type ")
+	b.WriteString(e.implTy.String())
+	b.WriteString(" cannot be registered as service type ")
+	b.WriteString(e.svcTy.String())
 	return b.String()
 }
 
+// ErrNotImplements matches NotImplementsError with errors.Is.
+var ErrNotImplements = errors.New("interface not implemented")
+
 // NotImplementsError occurs when a user wants to register a interface as a service,
 // but the expected implementation struct does not implement it.
 type NotImplementsError struct {
@@ -141,6 +318,20 @@ type NotImplementsError struct {
 	actualImplTy reflect.Type
 }
 
+// InterfaceType returns the interface the user wanted to register.
+func (e NotImplementsError) InterfaceType() reflect.Type {
+	return e.ifTy
+}
+
+// ImplType returns the type that does not implement the interface.
+func (e NotImplementsError) ImplType() reflect.Type {
+	return e.actualImplTy
+}
+
+func (e NotImplementsError) Is(target error) bool {
+	return target == ErrNotImplements
+}
+
 func (e NotImplementsError) Error() string {
 	var b strings.Builder
 	b.WriteString("interface ")
@@ -157,6 +348,16 @@ type BadPointerError struct {
 	structTy  reflect.Type
 }
 
+// PointerType returns the type of the service the user wanted to register.
+func (e BadPointerError) PointerType() reflect.Type {
+	return e.pointerTy
+}
+
+// StructType returns the implementation type that does not match the pointer.
+func (e BadPointerError) StructType() reflect.Type {
+	return e.structTy
+}
+
 func (e BadPointerError) Error() string {
 	var b strings.Builder
 	b.WriteString("service pointer type ")
@@ -172,6 +373,11 @@ type ImplNotStructError struct {
 	ty reflect.Type
 }
 
+// Type returns the implementation type that is not a struct.
+func (e ImplNotStructError) Type() reflect.Type {
+	return e.ty
+}
+
 func (e ImplNotStructError) Error() string {
 	return "implementation type " + e.ty.String() + " is not a struct"
 }
@@ -1,6 +1,7 @@
 package dino
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -18,13 +19,20 @@ func (s *myStruct1) Method1() {}
 
 type myStruct2 struct{}
 
+type myInterface2 interface {
+	Method2()
+}
+
+type myStruct3 struct {
+	Foo int
+}
+
+func (s *myStruct3) Method1() {}
+func (s *myStruct3) Method2() {}
+
 func TestAddFailsServiceType(t *testing.T) {
 	var err error
 
-	err = Add[myStruct1, myStruct1](&Container{})
-	assert.NotNil(t, err)
-	assert.ErrorAs(t, err, &InvalidServiceTypeError{})
-
 	err = Add[int, myStruct1](&Container{})
 	assert.NotNil(t, err)
 	assert.ErrorAs(t, err, &InvalidServiceTypeError{})
@@ -60,6 +68,49 @@ func TestAddFailsBadPointer(t *testing.T) {
 	assert.Contains(t, err.Error(), " dino.myStruct1")
 }
 
+func TestAddFailsStructNotSame(t *testing.T) {
+	err := Add[myStruct1, myStruct2](&Container{})
+	assert.NotNil(t, err)
+	assert.ErrorAs(t, err, &NotAssignableError{})
+
+	err = AddTransient[myStruct1, myStruct2](&Container{})
+	assert.NotNil(t, err)
+	assert.ErrorAs(t, err, &NotAssignableError{})
+
+	err = AddInstance[myStruct1](&Container{}, myStruct2{})
+	assert.NotNil(t, err)
+	assert.ErrorAs(t, err, &NotAssignableError{})
+}
+
+func TestAddStructValueIsCopied(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[myStruct1, myStruct1](c))
+	assert.Nil(t, AddTransientNamed[myStruct1, myStruct1](c, "transient"))
+	assert.Nil(t, AddInstanceNamed[myStruct1](c, "instance", myStruct1{Foo: 4}))
+
+	s, err := Get[myStruct1](c)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, s.Foo)
+	s.Foo = 5
+
+	s, err = Get[myStruct1](c)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, s.Foo)
+
+	s, err = GetNamed[myStruct1](c, "transient")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, s.Foo)
+
+	s, err = GetNamed[myStruct1](c, "instance")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, s.Foo)
+	s.Foo = 5
+
+	s, err = GetNamed[myStruct1](c, "instance")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, s.Foo)
+}
+
 func TestAddSucceedsAndIsSingleton(t *testing.T) {
 	c := &Container{}
 	err := Add[myInterface1, myStruct1](c)
@@ -84,22 +135,57 @@ func TestAddSucceedsAndIsSingleton(t *testing.T) {
 func TestAddInstanceFailsServiceType(t *testing.T) {
 	var err error
 
-	err = AddInstance[myStruct1](&Container{}, myStruct1{})
+	err = AddInstance[*myInterface1](&Container{}, myStruct1{})
 	assert.NotNil(t, err)
 	assert.ErrorAs(t, err, &InvalidServiceTypeError{})
+	assert.Contains(t, err.Error(), "*dino.myInterface1")
+}
+
+func TestAddInstanceFailsNotAssignable(t *testing.T) {
+	var err error
 
 	err = AddInstance[int](&Container{}, myStruct1{})
 	assert.NotNil(t, err)
-	assert.ErrorAs(t, err, &InvalidServiceTypeError{})
+	assert.ErrorAs(t, err, &NotAssignableError{})
 
 	err = AddInstance[string](&Container{}, myStruct1{})
 	assert.NotNil(t, err)
-	assert.ErrorAs(t, err, &InvalidServiceTypeError{})
+	assert.ErrorAs(t, err, &NotAssignableError{})
 
-	err = AddInstance[*myInterface1](&Container{}, myStruct1{})
+	err = AddInstance[*int](&Container{}, "foo")
 	assert.NotNil(t, err)
-	assert.ErrorAs(t, err, &InvalidServiceTypeError{})
-	assert.Contains(t, err.Error(), "*dino.myInterface1")
+	assert.ErrorAs(t, err, &NotAssignableError{})
+	assert.Regexp(t, "string.*\\*int", err.Error())
+}
+
+func TestAddInstanceNonStructSucceeds(t *testing.T) {
+	type (
+		Port   int
+		Config map[string]string
+		Clock  func() int
+	)
+
+	c := &Container{}
+	assert.Nil(t, AddInstance[Port](c, 8080))
+	assert.Nil(t, AddInstance[Config](c, map[string]string{"foo": "bar"}))
+	assert.Nil(t, AddInstance[Clock](c, func() int { return 4 }))
+	assert.Nil(t, AddInstanceNamed[[]string](c, "hosts", []string{"a", "b"}))
+
+	port, err := Get[Port](c)
+	assert.Nil(t, err)
+	assert.Equal(t, Port(8080), port)
+
+	config, err := Get[Config](c)
+	assert.Nil(t, err)
+	assert.Equal(t, "bar", config["foo"])
+
+	clock, err := Get[Clock](c)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, clock())
+
+	hosts, err := GetNamed[[]string](c, "hosts")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, hosts)
 }
 
 func TestAddInstanceFailsNotImplements(t *testing.T) {
@@ -130,10 +216,6 @@ func TestAddInstanceStructPtrSucceeds(t *testing.T) {
 func TestAddTransientFailsServiceType(t *testing.T) {
 	var err error
 
-	err = AddTransient[myStruct1, myStruct1](&Container{})
-	assert.NotNil(t, err)
-	assert.ErrorAs(t, err, &InvalidServiceTypeError{})
-
 	err = AddTransient[int, myStruct1](&Container{})
 	assert.NotNil(t, err)
 	assert.ErrorAs(t, err, &InvalidServiceTypeError{})
@@ -193,3 +275,163 @@ func TestAddTransientErrorsCyclicDependency(t *testing.T) {
 	assert.ErrorAs(t, err, &CyclicDependencyError{})
 	assert.Regexp(t, "MYIF .transient.$", err.Error())
 }
+
+func TestAddFactoryFailsServiceType(t *testing.T) {
+	err := AddFactory(&Container{}, func(c *Container) (*myInterface1, error) {
+		return nil, nil
+	})
+	assert.NotNil(t, err)
+	assert.ErrorAs(t, err, &InvalidServiceTypeError{})
+}
+
+func TestAddFactorySucceedsAndIsSingleton(t *testing.T) {
+	type Counter func() int
+
+	c := &Container{}
+	calls := 0
+	err := AddFactory(c, func(c *Container) (Counter, error) {
+		calls++
+		n := 0
+		return func() int { n++; return n }, nil
+	})
+	assert.Nil(t, err)
+
+	counter, err := Get[Counter](c)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, counter())
+
+	counter, err = Get[Counter](c)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, counter())
+	assert.Equal(t, 1, calls)
+}
+
+func TestAddTransientFactorySucceedsAndIsTransient(t *testing.T) {
+	c := &Container{}
+	calls := 0
+	err := AddTransientFactoryNamed(c, "calls", func(c *Container) (int, error) {
+		calls++
+		return calls, nil
+	})
+	assert.Nil(t, err)
+
+	n, err := GetNamed[int](c, "calls")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+
+	n, err = GetNamed[int](c, "calls")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestAddFactoryErrorsAreReturned(t *testing.T) {
+	myErr := errors.New("factory failed")
+
+	c := &Container{}
+	assert.Nil(t, AddFactory(c, func(c *Container) (myInterface1, error) {
+		return nil, myErr
+	}))
+
+	_, err := Get[myInterface1](c)
+	assert.ErrorIs(t, err, myErr)
+}
+
+func TestAddFactoryErrorsCyclicDependency(t *testing.T) {
+	type (
+		A struct{}
+		B struct {
+			ADep *A
+		}
+	)
+
+	c := &Container{}
+	assert.Nil(t, AddTransientFactory(c, func(c *Container) (int, error) {
+		n, err := Get[int](c)
+		return n + 1, err
+	}))
+	assert.Nil(t, AddFactory(c, func(c *Container) (*A, error) {
+		_, err := Get[*B](c)
+		return &A{}, err
+	}))
+	assert.Nil(t, Add[*B, B](c))
+
+	_, err := Get[int](c)
+	assert.ErrorIs(t, err, ErrCyclicDependency)
+
+	_, err = Get[*A](c)
+	assert.ErrorIs(t, err, ErrCyclicDependency)
+}
+
+func TestAddFactoryContainersCanBeKept(t *testing.T) {
+	type Lazy func() (int, error)
+
+	c := &Container{}
+	assert.Nil(t, AddInstance[int](c, 42))
+	assert.Nil(t, AddTransientFactory(c, func(c *Container) (Lazy, error) {
+		return func() (int, error) { return Get[int](c) }, nil
+	}))
+	assert.Nil(t, AddTransientFactory(c, func(c *Container) (string, error) {
+		// Registrations made by factories land in the container itself
+		return "registered", AddInstanceNamed[string](c, "late", "value")
+	}))
+
+	lazy := MustGet[Lazy](c)
+	n, err := lazy()
+	assert.Nil(t, err)
+	assert.Equal(t, 42, n)
+
+	assert.Equal(t, "registered", MustGet[string](c))
+	assert.Equal(t, "value", MustGetNamed[string](c, "late"))
+}
+
+func TestAddAsFailsNotImplements(t *testing.T) {
+	c := &Container{}
+	err := AddAs[myStruct1](c, As[myInterface1](), As[myInterface2]())
+	assert.NotNil(t, err)
+	assert.ErrorAs(t, err, &NotImplementsError{})
+	assert.ErrorIs(t, err, ErrNotImplements)
+	assert.Regexp(t, "myInterface2.*myStruct1", err.Error())
+
+	var notImpl NotImplementsError
+	assert.ErrorAs(t, err, &notImpl)
+	assert.Equal(t, getType[myInterface2](), notImpl.InterfaceType())
+	assert.Equal(t, getType[myStruct1](), notImpl.ImplType())
+
+	// Nothing should have been registered
+	_, err = Get[myInterface1](c)
+	assert.ErrorAs(t, err, &BindingMissingError{})
+	_, err = Get[*myStruct1](c)
+	assert.ErrorAs(t, err, &BindingMissingError{})
+}
+
+func TestAddAsFailsBadTypes(t *testing.T) {
+	err := AddAs[*myStruct1](&Container{})
+	assert.ErrorAs(t, err, &ImplNotStructError{})
+
+	err = AddAs[myStruct1](&Container{}, As[*myStruct2]())
+	assert.ErrorAs(t, err, &BadPointerError{})
+
+	err = AddAs[myStruct1](&Container{}, As[myStruct1]())
+	assert.ErrorAs(t, err, &InvalidServiceTypeError{})
+}
+
+func TestAddAsSharesSingleton(t *testing.T) {
+	c := &Container{}
+	err := AddAs[myStruct3](c, As[myInterface1](), AsNamed[myInterface2]("two"))
+	assert.Nil(t, err)
+
+	s1, err := Get[myInterface1](c)
+	assert.Nil(t, err)
+	s2, err := GetNamed[myInterface2](c, "two")
+	assert.Nil(t, err)
+	s3, err := Get[*myStruct3](c)
+	assert.Nil(t, err)
+
+	s3.Foo = 4
+	assert.Same(t, s3, s1)
+	assert.Same(t, s3, s2)
+	assert.Equal(t, 4, s1.(*myStruct3).Foo)
+
+	_, err = Get[myInterface2](c)
+	assert.ErrorAs(t, err, &BindingMissingError{})
+}
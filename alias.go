@@ -0,0 +1,43 @@
+package dino
+
+import (
+	"reflect"
+)
+
+// Alias makes a service of type TFrom available as type TTo in a global namespace.
+//
+// The alias gets resolved on each request, so it always provides
+// whatever is currently registered as TFrom.
+func Alias[TTo any, TFrom any](c *Container) error {
+	return AliasNamed[TTo, TFrom](c, "", "")
+}
+
+// AliasNamed makes a service of type TFrom registered under the target namespace
+// available as type TTo under a provided namespace.
+//
+// The alias gets resolved on each request, so it always provides
+// whatever is currently registered as TFrom under the target namespace.
+// Calling AliasNamed again for the same type and name re-points the alias.
+func AliasNamed[TTo any, TFrom any](c *Container, name string, targetName string) error {
+	tTo, tFrom := getTypes[TTo, TFrom]()
+
+	if !isValidServiceType(tTo) {
+		return InvalidServiceTypeError{ty: tTo}
+	}
+
+	switch tTo.Kind() {
+	case reflect.Interface:
+		if !tFrom.Implements(tTo) {
+			return NotImplementsError{ifTy: tTo, actualImplTy: tFrom}
+		}
+	default:
+		if !tFrom.AssignableTo(tTo) {
+			return NotAssignableError{svcTy: tTo, implTy: tFrom}
+		}
+	}
+
+	return c.store(tTo, name, &aliasBinding{
+		targetType: tFrom,
+		targetName: targetName,
+	})
+}
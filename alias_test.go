@@ -0,0 +1,76 @@
+package dino
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAliasFailsNotImplements(t *testing.T) {
+	err := Alias[myInterface1, *myStruct2](&Container{})
+	assert.NotNil(t, err)
+	assert.ErrorAs(t, err, &NotImplementsError{})
+	assert.Regexp(t, "myInterface1.*myStruct2", err.Error())
+}
+
+func TestAliasFailsNotAssignable(t *testing.T) {
+	err := Alias[*myStruct1, *myStruct2](&Container{})
+	assert.NotNil(t, err)
+	assert.ErrorAs(t, err, &NotAssignableError{})
+
+	err = Alias[*myInterface1, myInterface1](&Container{})
+	assert.NotNil(t, err)
+	assert.ErrorAs(t, err, &InvalidServiceTypeError{})
+}
+
+func TestAliasProvidesSameInstance(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[*myStruct1, myStruct1](c))
+	assert.Nil(t, Alias[myInterface1, *myStruct1](c))
+
+	s1, err := Get[*myStruct1](c)
+	assert.Nil(t, err)
+	s2, err := Get[myInterface1](c)
+	assert.Nil(t, err)
+	assert.Same(t, s1, s2)
+}
+
+func TestAliasNamedCanBeRepointed(t *testing.T) {
+	c := &Container{}
+	accounts := &myStruct1{Foo: 1}
+	billing := &myStruct1{Foo: 2}
+	assert.Nil(t, AddInstanceNamed[*myStruct1](c, "accounts", accounts))
+	assert.Nil(t, AddInstanceNamed[*myStruct1](c, "billing", billing))
+	assert.Nil(t, AliasNamed[*myStruct1, *myStruct1](c, "primary", "accounts"))
+
+	consumer := &struct {
+		DB *myStruct1 `dino:"named:primary"`
+	}{}
+	assert.Nil(t, injectFields(reflect.ValueOf(consumer), c, nil))
+	assert.Same(t, accounts, consumer.DB)
+
+	assert.Nil(t, AliasNamed[*myStruct1, *myStruct1](c, "primary", "billing"))
+	s, err := GetNamed[*myStruct1](c, "primary")
+	assert.Nil(t, err)
+	assert.Same(t, billing, s)
+}
+
+func TestAliasMissingTargetErrors(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AliasNamed[*myStruct1, *myStruct1](c, "primary", "accounts"))
+
+	_, err := GetNamed[*myStruct1](c, "primary")
+	assert.ErrorAs(t, err, &BindingMissingError{})
+	assert.Contains(t, err.Error(), "accounts")
+}
+
+func TestAliasErrorsCyclicDependency(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AliasNamed[*myStruct1, *myStruct1](c, "a", "b"))
+	assert.Nil(t, AliasNamed[*myStruct1, *myStruct1](c, "b", "a"))
+
+	_, err := GetNamed[*myStruct1](c, "a")
+	assert.ErrorAs(t, err, &CyclicDependencyError{})
+	assert.Regexp(t, "MYSTRUCT1 .alias.$", err.Error())
+}
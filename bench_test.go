@@ -0,0 +1,173 @@
+package dino
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// graphShapes lists depths and widths of transient graphs to benchmark.
+var graphShapes = []struct {
+	depth int
+	width int
+}{
+	{depth: 1, width: 1},
+	{depth: 4, width: 1},
+	{depth: 16, width: 1},
+	{depth: 4, width: 2},
+	{depth: 4, width: 4},
+	{depth: 2, width: 16},
+}
+
+// newBenchGraph creates a container with a graph of transients, in which every struct
+// has width fields pointing to structs a level below, and returns the type of its root.
+func newBenchGraph(depth int, width int) (*Container, reflect.Type) {
+	c := &Container{}
+	ty := getType[myStruct1]()
+	must(AddTransient[*myStruct1, myStruct1](c))
+
+	for level := 1; level < depth; level++ {
+		fields := make([]reflect.StructField, width)
+		for i := range fields {
+			fields[i] = reflect.StructField{
+				Name: fmt.Sprintf("F%d", i),
+				Type: reflect.PointerTo(ty),
+			}
+		}
+
+		ty = reflect.StructOf(fields)
+		must(c.store(reflect.PointerTo(ty), "", &transientBinding{implType: ty}))
+	}
+
+	return c, reflect.PointerTo(ty)
+}
+
+// runSealedAndUnsealed runs a benchmark against both an unsealed and a sealed container.
+func runSealedAndUnsealed(b *testing.B, setup func() *Container, bench func(b *testing.B, c *Container)) {
+	for _, seal := range []bool{false, true} {
+		name := "Unsealed"
+		if seal {
+			name = "Sealed"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			c := setup()
+			if seal {
+				must(c.Seal())
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			bench(b, c)
+		})
+	}
+}
+
+func BenchmarkSingletonHit(b *testing.B) {
+	setup := func() *Container {
+		c := &Container{}
+		must(Add[myInterface1, myStruct1](c))
+		MustGet[myInterface1](c)
+		return c
+	}
+
+	runSealedAndUnsealed(b, setup, func(b *testing.B, c *Container) {
+		for i := 0; i < b.N; i++ {
+			if _, err := Get[myInterface1](c); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkInstanceHit(b *testing.B) {
+	setup := func() *Container {
+		c := &Container{}
+		must(AddInstance[*myStruct1](c, &myStruct1{}))
+		return c
+	}
+
+	runSealedAndUnsealed(b, setup, func(b *testing.B, c *Container) {
+		for i := 0; i < b.N; i++ {
+			if _, err := Get[*myStruct1](c); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkNamedLookup(b *testing.B) {
+	setup := func() *Container {
+		c := &Container{}
+		for i := 0; i < 100; i++ {
+			must(AddInstanceNamed[*myStruct1](c, fmt.Sprintf("name%d", i), &myStruct1{Foo: i}))
+		}
+		return c
+	}
+
+	runSealedAndUnsealed(b, setup, func(b *testing.B, c *Container) {
+		for i := 0; i < b.N; i++ {
+			if _, err := GetNamed[*myStruct1](c, "name42"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTransientGraph(b *testing.B) {
+	for _, shape := range graphShapes {
+		shape := shape
+		b.Run(fmt.Sprintf("Depth%dWidth%d", shape.depth, shape.width), func(b *testing.B) {
+			var root reflect.Type
+			setup := func() (c *Container) {
+				c, root = newBenchGraph(shape.depth, shape.width)
+				return
+			}
+
+			runSealedAndUnsealed(b, setup, func(b *testing.B, c *Container) {
+				for i := 0; i < b.N; i++ {
+					if _, err := c.tryGet(root, "", nil); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkParallelSingletonHit(b *testing.B) {
+	setup := func() *Container {
+		c := &Container{}
+		must(Add[myInterface1, myStruct1](c))
+		return c
+	}
+
+	runSealedAndUnsealed(b, setup, func(b *testing.B, c *Container) {
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if _, err := Get[myInterface1](c); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	})
+}
+
+func BenchmarkParallelTransientGraph(b *testing.B) {
+	var root reflect.Type
+	setup := func() (c *Container) {
+		c, root = newBenchGraph(4, 2)
+		return
+	}
+
+	runSealedAndUnsealed(b, setup, func(b *testing.B, c *Container) {
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if _, err := c.tryGet(root, "", nil); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	})
+}
@@ -2,6 +2,7 @@ package dino
 
 import (
 	"reflect"
+	"sync/atomic"
 )
 
 // Binding describes a service.
@@ -13,49 +14,190 @@ type Binding interface {
 	Provide(c *Container, chain []DepLink) (reflect.Value, error)
 }
 
+// factoryFunc creates a new instance of a service.
+type factoryFunc func(c *Container) (reflect.Value, error)
+
+// factoryCall describes a call of a factory, which might request services from the container.
+type factoryCall struct {
+	chain []DepLink // Bindings called before the factory, ending with its own.
+	done  uint32    // Set atomically to 1 once the factory returns.
+}
+
+// callFactory calls a factory with a container standing for c,
+// which remembers the chain of bindings leading to the factory.
+// Services requested by the factory continue the chain, so that cycles going through factories get detected.
+func (c *Container) callFactory(factory factoryFunc, chain []DepLink) (reflect.Value, error) {
+	call := &factoryCall{chain: chain[:len(chain):len(chain)]}
+	defer atomic.StoreUint32(&call.done, 1)
+
+	return factory(&Container{parent: c, call: call})
+}
+
+// newChain returns the chain a new request to the container starts with.
+//
+// Factories might keep their container to request services later on,
+// so the chain of the factory only gets continued while it is being called.
+func (c *Container) newChain() []DepLink {
+	if c.call != nil && atomic.LoadUint32(&c.call.done) == 0 {
+		return c.call.chain
+	}
+	return make([]DepLink, 0, 4)
+}
+
+// unwrap returns the container that a container handed to a factory stands for.
+func (c *Container) unwrap() *Container {
+	for c.call != nil {
+		c = c.parent
+	}
+	return c
+}
+
+// wrapFactory converts a user-provided factory of a service to a factoryFunc.
+func wrapFactory[T any](factory func(c *Container) (T, error)) factoryFunc {
+	return func(c *Container) (reflect.Value, error) {
+		svc, err := factory(c)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		// Take the value through a pointer, so that it keeps its static type,
+		// even if T is an interface.
+		return reflect.ValueOf(&svc).Elem(), nil
+	}
+}
+
 // singletonBinding describes a service that persists
 // for the whole lifetime of an application.
+//
+// If factory is set, it is used to create the service
+// instead of injecting fields into a new implType struct.
+// If byValue is set, the struct gets provided as a copy instead of a pointer.
+//
+// Singletons of a container get built one at a time, under the build lock of the container.
+// Goroutines requesting a singleton which is not ready yet wait for the build in progress,
+// so the instance is only ever built once.
 type singletonBinding struct {
 	implType reflect.Type
-	instance reflect.Value
-	built    bool
+	factory  factoryFunc
+	byValue  bool
+	instance atomic.Value    // Holds a *builtInstance, which is nil until the instance is ready.
+	build    *singletonBuild // Set while the instance is being built, only used by the goroutine building it.
+}
+
+// builtInstance is the published instance of a singleton.
+type builtInstance struct {
+	value reflect.Value
+}
+
+// singletonBuild describes an instance of a singleton being built.
+type singletonBuild struct {
+	owner    *Container    // Container building the instance, whose build lock is held.
+	instance reflect.Value // Instance being built, if the singleton has one before it is ready.
 }
 
 func (b *singletonBinding) Provide(c *Container, chain []DepLink) (svc reflect.Value, err error) {
-	if b.built {
-		return b.instance, nil
+	if instance, ok := b.current(); ok {
+		c.observeCacheHit(chain)
+		return b.provided(instance), nil
 	}
 
-	b.instance = reflect.New(b.implType)
-	b.built = true
-	err = injectFields(b.instance, c, chain)
-	if err == nil {
-		svc = b.instance
+	// The chain only contains singletons being built by the current goroutine,
+	// so if this one is among them, it depends on itself and gets its own instance, even though it is not ready yet.
+	// Factories do not have an instance until they return, so they cannot depend on themselves.
+	if isCyclic(chain, b) {
+		if !b.build.instance.IsValid() {
+			return svc, CyclicDependencyError{chain: chain}
+		}
+		return b.provided(b.build.instance), nil
 	}
 
-	return
+	defer c.lockBuilds(chain)()
+	// Some other goroutine might have built the instance in the meantime
+	if instance, ok := b.current(); ok {
+		c.observeCacheHit(chain)
+		return b.provided(instance), nil
+	}
+
+	b.build = &singletonBuild{owner: c}
+	defer func() { b.build = nil }()
+
+	timer := c.startBuild()
+	if b.factory != nil {
+		svc, err = c.callFactory(b.factory, chain)
+		if err != nil {
+			return svc, wrapFactoryError(chain, err)
+		}
+	} else {
+		svc = reflect.New(b.implType)
+		b.build.instance = svc
+		if err = injectFields(svc, c, chain); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+
+	b.instance.Store(&builtInstance{value: svc})
+	c.trackBuilt(b)
+	timer.done(chain)
+	return b.provided(svc), nil
+}
+
+// current returns the published instance of the singleton, if it is ready.
+func (b *singletonBinding) current() (reflect.Value, bool) {
+	built, _ := b.instance.Load().(*builtInstance)
+	if built == nil {
+		return reflect.Value{}, false
+	}
+	return built.value, true
+}
+
+// isBuilt checks whether the instance of the singleton is ready.
+func (b *singletonBinding) isBuilt() bool {
+	_, ok := b.current()
+	return ok
+}
+
+// provided returns an instance in a form that should be handed out to consumers.
+func (b *singletonBinding) provided(instance reflect.Value) reflect.Value {
+	if b.byValue && b.factory == nil {
+		return instance.Elem()
+	}
+	return instance
 }
 
 // transientBinding describes a service that gets recreated
 // each time it is requested from the container.
+//
+// If factory is set, it is used to create the service
+// instead of injecting fields into a new implType struct.
+// If byValue is set, the struct gets provided as a copy instead of a pointer.
 type transientBinding struct {
 	implType reflect.Type
+	factory  factoryFunc
+	byValue  bool
 }
 
 func (b *transientBinding) Provide(c *Container, chain []DepLink) (svc reflect.Value, err error) {
 
-	if len(chain) >= 2 {
-		chainUntilNow := chain[:len(chain)-1]
-		for _, link := range chainUntilNow {
-			if link.binding == b {
-				err = CyclicDependencyError{chain: chain}
-				return
-			}
+	if isCyclic(chain, b) {
+		err = CyclicDependencyError{chain: chain}
+		return
+	}
+
+	timer := c.startBuild()
+	if b.factory != nil {
+		svc, err = c.callFactory(b.factory, chain)
+		err = wrapFactoryError(chain, err)
+	} else {
+		svc = reflect.New(b.implType)
+		err = injectFields(svc, c, chain)
+		if b.byValue {
+			svc = svc.Elem()
 		}
 	}
 
-	svc = reflect.New(b.implType)
-	err = injectFields(svc, c, chain)
+	if err == nil {
+		timer.done(chain)
+	}
 	return
 }
 
@@ -68,3 +210,21 @@ func (b *instanceBinding) Provide(c *Container, chain []DepLink) (svc reflect.Va
 	svc = b.instance
 	return
 }
+
+// aliasBinding describes a service that is provided by another binding.
+//
+// The target gets looked up on each request,
+// so re-registering it is reflected by the alias.
+type aliasBinding struct {
+	targetType reflect.Type
+	targetName string
+}
+
+func (b *aliasBinding) Provide(c *Container, chain []DepLink) (svc reflect.Value, err error) {
+	if isCyclic(chain, b) {
+		err = CyclicDependencyError{chain: chain}
+		return
+	}
+
+	return c.tryGet(b.targetType, b.targetName, chain)
+}
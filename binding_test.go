@@ -2,7 +2,10 @@ package dino
 
 import (
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -24,6 +27,174 @@ func TestSingletonReturnsSame(t *testing.T) {
 	assert.Same(t, foo1.Interface(), foo2.Interface())
 }
 
+func TestSingletonIsSharedConcurrently(t *testing.T) {
+	type foo struct {
+		Dep *myStruct1
+	}
+
+	c := &Container{}
+	assert.Nil(t, Add[*myStruct1, myStruct1](c))
+	assert.Nil(t, Add[*foo, foo](c))
+
+	results := make([]*foo, 8)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := Get[*foo](c)
+			assert.Nil(t, err)
+			results[i] = v
+		}(i)
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		assert.Same(t, results[0], result)
+	}
+}
+
+// finishesIn fails the test, if a function does not return in time, eg. because of a deadlock.
+func finishesIn(t *testing.T, timeout time.Duration, fn func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		t.Fatal("function did not return in time")
+	}
+}
+
+type ping struct {
+	Pong *pong
+}
+
+type pong struct {
+	Ping *ping
+}
+
+func TestSingletonsDependingOnEachOtherConcurrently(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		c := &Container{}
+		assert.Nil(t, Add[*ping, ping](c))
+		assert.Nil(t, Add[*pong, pong](c))
+
+		finishesIn(t, 5*time.Second, func() {
+			start := make(chan struct{})
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				<-start
+				_, err := Get[*ping](c)
+				assert.Nil(t, err)
+			}()
+			go func() {
+				defer wg.Done()
+				<-start
+				_, err := Get[*pong](c)
+				assert.Nil(t, err)
+			}()
+			close(start)
+			wg.Wait()
+		})
+
+		p, q := MustGet[*ping](c), MustGet[*pong](c)
+		assert.Same(t, p, q.Ping)
+		assert.Same(t, q, p.Pong)
+	}
+}
+
+type closeCounter struct {
+	closed *int32
+}
+
+func (c *closeCounter) Close() error {
+	atomic.AddInt32(c.closed, 1)
+	return nil
+}
+
+func TestSingletonFactoryIsCalledOnceConcurrently(t *testing.T) {
+	var calls, closed int32
+	c := &Container{}
+	assert.Nil(t, AddFactory(c, func(c *Container) (*closeCounter, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return &closeCounter{closed: &closed}, nil
+	}))
+
+	results := make([]*closeCounter, 8)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = MustGet[*closeCounter](c)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, result := range results {
+		assert.Same(t, results[0], result)
+	}
+
+	assert.Nil(t, c.Close())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&closed))
+}
+
+func TestSingletonFactoryLeadingBackToItself(t *testing.T) {
+	type (
+		A struct{}
+		B struct {
+			ADep *A
+		}
+	)
+
+	c := &Container{}
+	assert.Nil(t, AddFactory(c, func(c *Container) (*A, error) {
+		_, err := Get[*B](c)
+		return &A{}, err
+	}))
+	assert.Nil(t, Add[*B, B](c))
+
+	finishesIn(t, 5*time.Second, func() {
+		_, err := Get[*A](c)
+		assert.ErrorIs(t, err, ErrCyclicDependency)
+
+		// The failed attempt does not leave anything behind
+		_, err = Get[*A](c)
+		assert.ErrorIs(t, err, ErrCyclicDependency)
+	})
+}
+
+func TestSingletonIsRebuiltAfterError(t *testing.T) {
+	type foo struct {
+		Dep myInterface1
+	}
+
+	c := &Container{}
+	b := &singletonBinding{
+		implType: getType[foo](),
+	}
+	assert.Nil(t, AddTransientFactory(c, func(c *Container) (myInterface1, error) {
+		return nil, assert.AnError
+	}))
+
+	_, err := b.Provide(c, nil)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.False(t, b.isBuilt())
+
+	assert.Nil(t, AddInstance[myInterface1](c, &myStruct1{}))
+	v, err := b.Provide(c, nil)
+	assert.Nil(t, err)
+	assert.NotNil(t, v.Interface().(*foo).Dep)
+}
+
 func TestInstanceRefReturnsSame(t *testing.T) {
 	type foo struct {
 		bar int
@@ -101,3 +272,20 @@ func TestTransientReturnsDifferent(t *testing.T) {
 
 	assert.NotSame(t, f, f2)
 }
+
+type selfReferencing struct {
+	Self *selfReferencing
+}
+
+func TestConditionalSingletonDependingOnItself(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AddIf(c, When("always", func() bool { return true }), func(c *Container) error {
+		return Add[*selfReferencing, selfReferencing](c)
+	}))
+
+	finishesIn(t, 5*time.Second, func() {
+		v, err := Get[*selfReferencing](c)
+		assert.Nil(t, err)
+		assert.Same(t, v, v.Self)
+	})
+}
@@ -1,6 +1,7 @@
 package dino
 
 import (
+	"errors"
 	"reflect"
 	"strings"
 )
@@ -8,9 +9,29 @@ import (
 // DepLink describes a stack frame of currently called bindings.
 type DepLink struct {
 	ty      reflect.Type // Type requested from the container.
+	name    string       // Namespace the type was requested from.
 	binding Binding      // Binding used to realize the request.
+	id      uint64       // ID of the resolution, only set if it is being observed.
 }
 
+// Type returns the type requested from the container.
+func (l DepLink) Type() reflect.Type {
+	return l.ty
+}
+
+// Name returns the namespace the type was requested from.
+func (l DepLink) Name() string {
+	return l.name
+}
+
+// Lifetime returns the lifetime of the binding used to realize the request.
+func (l DepLink) Lifetime() Lifetime {
+	return lifetimeOf(l.binding)
+}
+
+// ErrCyclicDependency matches CyclicDependencyError with errors.Is.
+var ErrCyclicDependency = errors.New("cyclic dependency")
+
 // CyclicDependencyError occurs when a container cannot construct a service,
 // because a transient service depends on itself.
 //
@@ -19,10 +40,88 @@ type CyclicDependencyError struct {
 	chain []DepLink
 }
 
+// Chain returns the services being resolved when the cycle was found.
+func (e CyclicDependencyError) Chain() []DepLink {
+	return append([]DepLink(nil), e.chain...)
+}
+
+func (e CyclicDependencyError) Is(target error) bool {
+	return target == ErrCyclicDependency
+}
+
 func (e CyclicDependencyError) Error() string {
 	return "Cannot satisfy cyclic dependency: " + formatChain(e.chain, true)
 }
 
+// ResolveError occurs when a factory fails to construct a service.
+// It wraps the error of the factory and describes the services being resolved at the time.
+type ResolveError struct {
+	chain []DepLink
+	err   error
+}
+
+// Chain returns the services being resolved, starting with the one requested directly
+// (or injected into the field described by a wrapping FieldError) and ending with the one whose factory has failed.
+func (e ResolveError) Chain() []DepLink {
+	return append([]DepLink(nil), e.chain...)
+}
+
+func (e ResolveError) Unwrap() error {
+	return e.err
+}
+
+func (e ResolveError) Error() string {
+	return "while resolving " + formatPath(e.chain) + ": " + e.err.Error()
+}
+
+// wrapFactoryError wraps an error returned by the factory of the service at the end of the chain.
+//
+// Factories resolving their own dependencies start new chains,
+// so errors of such dependencies get their paths joined with the chain.
+func wrapFactoryError(chain []DepLink, err error) error {
+	if err == nil || len(chain) == 0 {
+		return err
+	}
+
+	path := append([]DepLink(nil), chain...)
+	if inner, ok := err.(ResolveError); ok {
+		// Factories continue the chain of their callers, but errors might come from elsewhere
+		if hasPrefix(inner.chain, chain) {
+			path = append(path[:0], inner.chain...)
+		} else {
+			path = append(path, inner.chain...)
+		}
+		err = inner.err
+	}
+	return ResolveError{chain: path, err: err}
+}
+
+// hasPrefix checks whether a chain starts with the same resolutions as another one.
+func hasPrefix(chain []DepLink, prefix []DepLink) bool {
+	if len(chain) < len(prefix) {
+		return false
+	}
+	for i, link := range prefix {
+		if chain[i].ty != link.ty || chain[i].name != link.name || chain[i].binding != link.binding {
+			return false
+		}
+	}
+	return true
+}
+
+// formatPath describes a chain of dependencies as a short, human-readable path,
+// eg. "*app.Server → *db.Pool (named:main)".
+func formatPath(chain []DepLink) string {
+	var b strings.Builder
+	for i, link := range chain {
+		if i > 0 {
+			b.WriteString(" → ")
+		}
+		b.WriteString(formatKey(link.ty, link.name))
+	}
+	return b.String()
+}
+
 // formatChain describes a chain of dependencies as a human-readable string.
 //
 // If highlightLast is set, it will print all links matching the last one as uppercase.
@@ -40,25 +139,46 @@ func formatChain(chain []DepLink, highlightLast bool) string {
 		if link.ty != nil {
 			svcName = link.ty.String()
 		}
-		if highlightLast && link == lastLink {
+		if highlightLast && link.ty == lastLink.ty && link.binding == lastLink.binding {
 			svcName = strings.ToUpper(svcName)
 		}
 		b.WriteString(svcName)
 		b.WriteString(" (")
-		typeName := "???"
-		{
-			bty := reflect.TypeOf(link.binding)
-			if bty != nil {
-				name := bty.Name()
-				if name == "" && bty.Kind() == reflect.Pointer {
-					name = bty.Elem().Name()
-				}
-				typeName = name
-			}
-		}
-		b.WriteString(strings.TrimSuffix(typeName, "Binding"))
+		b.WriteString(bindingKind(link.binding))
 		b.WriteString(") ---> ")
 	}
 
 	return strings.TrimSuffix(b.String(), " ---> ")
 }
+
+// bindingKind returns a short, human-readable name of a binding implementation,
+// eg. "singleton" for a *singletonBinding.
+func bindingKind(binding Binding) string {
+	bty := reflect.TypeOf(binding)
+	if bty == nil {
+		return "???"
+	}
+
+	name := bty.Name()
+	if name == "" && bty.Kind() == reflect.Pointer {
+		name = bty.Elem().Name()
+	}
+
+	return strings.TrimSuffix(name, "Binding")
+}
+
+// isCyclic checks whether a binding at the end of the chain
+// has already been called earlier in the chain.
+func isCyclic(chain []DepLink, binding Binding) bool {
+	if len(chain) < 2 {
+		return false
+	}
+
+	for _, link := range chain[:len(chain)-1] {
+		if link.binding == binding {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,6 +1,7 @@
 package dino
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,3 +22,69 @@ func TestChainGetsFormattedCorrectly(t *testing.T) {
 	assert.Equal(t, "dino.foo (singleton) ---> dino.bar (singleton)", formatChain(chain, false))
 	assert.Equal(t, "dino.foo (singleton) ---> DINO.BAR (singleton)", formatChain(chain, true))
 }
+
+func TestFactoryErrorsDescribeResolutionPath(t *testing.T) {
+	myErr := errors.New("no disk")
+	type store struct{}
+	type cache struct {
+		Store *store `dino:"named:main"`
+	}
+
+	c := &Container{}
+	assert.Nil(t, AddFactoryNamed(c, "main", func(c *Container) (*store, error) {
+		return nil, myErr
+	}))
+	assert.Nil(t, AddTransient[*cache, cache](c))
+
+	_, err := GetNamed[*store](c, "main")
+	assert.ErrorIs(t, err, myErr)
+	assert.EqualError(t, err, "while resolving *dino.store (named:main): no disk")
+
+	// Paths leading to fields are described by FieldErrors instead
+	_, err = Get[*cache](c)
+	assert.ErrorIs(t, err, myErr)
+	assert.EqualError(t, err, "*dino.cache.Store (named:main) → *dino.store: no disk")
+
+	var resolveErr ResolveError
+	assert.ErrorAs(t, err, &resolveErr)
+	chain := resolveErr.Chain()
+	assert.Len(t, chain, 1)
+	assert.Equal(t, getType[*store](), chain[0].Type())
+	assert.Equal(t, "main", chain[0].Name())
+	assert.Equal(t, LifetimeSingleton, chain[0].Lifetime())
+}
+
+func TestFactoryErrorsJoinPathsOfNestedResolutions(t *testing.T) {
+	type store struct{}
+	type cache struct{}
+
+	c := &Container{}
+	assert.Nil(t, AddFactory(c, func(c *Container) (*store, error) {
+		return nil, assert.AnError
+	}))
+	assert.Nil(t, AddFactory(c, func(c *Container) (*cache, error) {
+		_, err := Get[*store](c)
+		return &cache{}, err
+	}))
+
+	_, err := Get[*cache](c)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.EqualError(t, err, "while resolving *dino.cache → *dino.store: "+assert.AnError.Error())
+}
+
+func TestCyclicDependencyErrorIsInspectable(t *testing.T) {
+	type foo struct {
+		Foo *foo
+	}
+
+	c := &Container{}
+	assert.Nil(t, AddTransient[*foo, foo](c))
+
+	_, err := Get[*foo](c)
+	assert.ErrorIs(t, err, ErrCyclicDependency)
+
+	var cyclic CyclicDependencyError
+	assert.ErrorAs(t, err, &cyclic)
+	assert.Len(t, cyclic.Chain(), 2)
+	assert.Equal(t, getType[*foo](), cyclic.Chain()[1].Type())
+}
@@ -0,0 +1,137 @@
+package dino
+
+import (
+	"io"
+	"reflect"
+	"sync"
+)
+
+// NewChild creates a container, which falls back to this one
+// when it does not have a binding for a requested service.
+//
+// Registrations made in the child stay local to it, so it can override bindings of its parent
+// for a part of an application, eg. a tenant or a plugin.
+// Singletons registered in the child live as long as the child does, while singletons found
+// in the parent are built and kept by the parent, with dependencies from the parent,
+// so that they can be shared by all of its children.
+// Other services found in the parent, eg. transients, get their dependencies from the child.
+//
+// The child uses the handler of missing services and the observer of the parent,
+// unless it gets its own.
+func (c *Container) NewChild() *Container {
+	return &Container{parent: c.unwrap()}
+}
+
+// Parent returns the container this one has been created from with NewChild, or nil.
+func (c *Container) Parent() *Container {
+	return c.unwrap().parent
+}
+
+// lookup retrieves the Binding for a provided type and name from the container or its ancestors,
+// along with the container that should ask the binding for the service.
+func (c *Container) lookup(ty reflect.Type, name string) (Binding, *Container, bool) {
+	for owner := c; owner != nil; owner = owner.parent {
+		// Containers handed to factories do not have bindings of their own
+		if owner.call != nil {
+			continue
+		}
+
+		b, ok := owner.tryLoad(ty, name)
+		if !ok {
+			continue
+		}
+
+		// Singletons must not depend on services of a single child, as they are shared by all of them
+		if _, isSingleton := b.(*singletonBinding); isSingleton {
+			return b, owner, true
+		}
+		return b, c, true
+	}
+
+	if b := inBinding(ty, name); b != nil {
+		return b, c, true
+	}
+	return nil, c, false
+}
+
+// has checks whether the container or any of its ancestors has a binding for a provided key.
+func (c *Container) has(key bindingKey) bool {
+	_, _, ok := c.lookup(key.ty, key.name)
+	return ok
+}
+
+// builtSingletons tracks singletons built by a container, so that they can be disposed of.
+type builtSingletons struct {
+	mu       sync.Mutex
+	bindings []*singletonBinding // In the order of building.
+	building sync.Mutex          // Held while singletons of the container are being built.
+}
+
+// lockBuilds makes sure that singletons of the container get built by one goroutine at a time
+// and returns a function letting the others continue.
+//
+// Singletons depend on each other, so the goroutine which is already building singletons
+// of the container does not wait for itself.
+func (c *Container) lockBuilds(chain []DepLink) (unlock func()) {
+	// Bindings can be asked for services without a container
+	if c == nil {
+		return func() {}
+	}
+
+	// The last binding is the one about to be built, which might be in the middle of being built by someone else
+	for i := 0; i < len(chain)-1; i++ {
+		if b, ok := chain[i].binding.(*singletonBinding); ok && b.build != nil && b.build.owner == c {
+			return func() {}
+		}
+	}
+
+	c.built.building.Lock()
+	return c.built.building.Unlock
+}
+
+// trackBuilt records that a singleton has been built by the container.
+func (c *Container) trackBuilt(b *singletonBinding) {
+	// Bindings can be asked for services without a container
+	if c == nil {
+		return
+	}
+
+	c.built.mu.Lock()
+	defer c.built.mu.Unlock()
+	c.built.bindings = append(c.built.bindings, b)
+}
+
+// Close disposes of services built by singletons of the container, which implement io.Closer,
+// in the reverse order of building them. The singletons get built again, if they are requested later.
+//
+// Services kept by parent containers and instances provided by the user are left as they are.
+// All the services get closed, even if some of them fail, and the first error is returned.
+func (c *Container) Close() error {
+	c = c.unwrap()
+	c.built.mu.Lock()
+	bindings := c.built.bindings
+	c.built.bindings = nil
+	c.built.mu.Unlock()
+
+	var firstErr error
+	closed := make(map[*singletonBinding]bool, len(bindings))
+	for i := len(bindings) - 1; i >= 0; i-- {
+		b := bindings[i]
+		instance, built := b.current()
+		if closed[b] || !built {
+			continue
+		}
+		closed[b] = true
+
+		closer, ok := b.provided(instance).Interface().(io.Closer)
+		b.reset()
+		if !ok {
+			continue
+		}
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,148 @@
+package dino
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type resource struct {
+	name   string
+	closed *[]string
+	err    error
+}
+
+func (r *resource) Close() error {
+	*r.closed = append(*r.closed, r.name)
+	return r.err
+}
+
+func TestChildFallsBackToParent(t *testing.T) {
+	parent := &Container{}
+	assert.Nil(t, Add[clock, realClock](parent))
+
+	child := parent.NewChild()
+	assert.Same(t, parent, child.Parent())
+	assert.Nil(t, Add[*scheduler, scheduler](child))
+
+	s, err := Get[*scheduler](child)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, s.Clock.Now())
+
+	// Registrations stay local to the child
+	_, err = Get[*scheduler](parent)
+	assert.ErrorIs(t, err, ErrBindingMissing)
+}
+
+func TestChildOverridesParent(t *testing.T) {
+	parent := &Container{}
+	assert.Nil(t, Add[clock, realClock](parent))
+
+	child := parent.NewChild()
+	assert.Nil(t, Add[clock, fakeClock](child))
+
+	assert.Equal(t, 2, MustGet[clock](child).Now())
+	assert.Equal(t, 1, MustGet[clock](parent).Now())
+}
+
+func TestParentSingletonsIgnoreChildOverrides(t *testing.T) {
+	parent := &Container{}
+	assert.Nil(t, Add[clock, realClock](parent))
+	assert.Nil(t, Add[*scheduler, scheduler](parent))
+
+	child := parent.NewChild()
+	assert.Nil(t, Add[clock, fakeClock](child))
+
+	s := MustGet[*scheduler](child)
+	assert.Equal(t, 1, s.Clock.Now())
+	assert.Same(t, s, MustGet[*scheduler](parent))
+	assert.Same(t, s, MustGet[*scheduler](parent.NewChild()))
+}
+
+func TestParentTransientsUseChildOverrides(t *testing.T) {
+	parent := &Container{}
+	assert.Nil(t, Add[clock, realClock](parent))
+	assert.Nil(t, AddTransient[*scheduler, scheduler](parent))
+
+	child := parent.NewChild()
+	assert.Nil(t, Add[clock, fakeClock](child))
+
+	assert.Equal(t, 2, MustGet[*scheduler](child).Clock.Now())
+	assert.Equal(t, 1, MustGet[*scheduler](parent).Clock.Now())
+}
+
+func TestChildSingletonsAreLocal(t *testing.T) {
+	parent := &Container{}
+	assert.Nil(t, Add[clock, realClock](parent))
+	assert.Nil(t, Add[*scheduler, scheduler](parent.NewChild()))
+
+	first, second := parent.NewChild(), parent.NewChild()
+	assert.Nil(t, Add[*scheduler, scheduler](first))
+	assert.Nil(t, Add[*scheduler, scheduler](second))
+	assert.NotSame(t, MustGet[*scheduler](first), MustGet[*scheduler](second))
+}
+
+func TestSealedChildFallsBackToParent(t *testing.T) {
+	parent := &Container{}
+	assert.Nil(t, Add[realClock, realClock](parent))
+
+	child := parent.NewChild()
+	assert.Nil(t, Alias[clock, realClock](child))
+	assert.Nil(t, Add[*scheduler, scheduler](child))
+	assert.Nil(t, child.Seal())
+
+	assert.Equal(t, 1, MustGet[*scheduler](child).Clock.Now())
+}
+
+func TestChildInheritsObserverAndMissingHandler(t *testing.T) {
+	parent := &Container{}
+	parent.SetMissingHandler(func(ty reflect.Type, name string) (reflect.Value, bool) {
+		return reflect.ValueOf(fakeClock{}), true
+	})
+
+	child := parent.NewChild()
+	assert.Nil(t, Add[*scheduler, scheduler](child))
+	assert.Equal(t, 2, MustGet[*scheduler](child).Clock.Now())
+
+	// A handler of the child takes precedence, even if it removes the one of the parent
+	child = parent.NewChild()
+	child.SetMissingHandler(nil)
+	assert.Nil(t, Add[*scheduler, scheduler](child))
+	assert.Nil(t, MustGet[*scheduler](child).Clock)
+}
+
+func TestCloseDisposesBuiltSingletons(t *testing.T) {
+	type first struct{ *resource }
+	type second struct{ *resource }
+	type third struct{ *resource }
+
+	var closed []string
+	parent := &Container{}
+	assert.Nil(t, AddFactory(parent, func(c *Container) (*third, error) {
+		return &third{&resource{name: "third", closed: &closed}}, nil
+	}))
+
+	child := parent.NewChild()
+	assert.Nil(t, AddFactory(child, func(c *Container) (*first, error) {
+		return &first{&resource{name: "first", closed: &closed}}, nil
+	}))
+	assert.Nil(t, AddFactory(child, func(c *Container) (*second, error) {
+		_, err := Get[*first](c)
+		return &second{&resource{name: "second", closed: &closed, err: errors.New("busy")}}, err
+	}))
+	assert.Nil(t, AddInstance[*resource](child, &resource{name: "instance", closed: &closed}))
+
+	s := MustGet[*second](child)
+	MustGet[*third](child)
+	MustGet[*resource](child)
+
+	assert.EqualError(t, child.Close(), "busy")
+	assert.Equal(t, []string{"second", "first"}, closed)
+
+	// Closed singletons get built again
+	assert.NotSame(t, s, MustGet[*second](child))
+	assert.Nil(t, parent.Close())
+	assert.Equal(t, []string{"second", "first", "third"}, closed)
+}
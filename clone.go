@@ -0,0 +1,250 @@
+package dino
+
+import (
+	"reflect"
+	"sync"
+)
+
+// CloneOption changes how a container gets cloned.
+type CloneOption struct {
+	deepCopy bool
+}
+
+// ShareInstances makes a clone provide the same objects as the original container
+// for registered instances and singletons that have already been built.
+//
+// This is the default.
+func ShareInstances() CloneOption {
+	return CloneOption{deepCopy: false}
+}
+
+// DeepCopyInstances makes a clone provide deep copies of registered instances
+// and singletons that have already been built, so that modifying them does not affect the original.
+//
+// Values are copied through pointers, interfaces, slices, maps and exported struct fields.
+// Unexported fields, functions and channels still refer to the same memory.
+func DeepCopyInstances() CloneOption {
+	return CloneOption{deepCopy: true}
+}
+
+// Clone creates an independent copy of the container.
+//
+// Registrations made in one of the containers do not affect the other.
+// Singletons that have not been built yet are built separately by each container,
+// while services that already exist are either shared or copied, depending on the options.
+// Bindings shared by several registrations (eg. with AddAs) stay shared in the clone,
+// and so do the handler of missing services and the observer.
+//
+// If the container has been sealed, so is the clone.
+func (c *Container) Clone(opts ...CloneOption) *Container {
+	c = c.unwrap()
+	cl := &cloner{
+		bindings: make(map[Binding]Binding),
+	}
+	for _, opt := range opts {
+		cl.deepCopy = opt.deepCopy
+	}
+	if cl.deepCopy {
+		cl.copier = &copier{seen: make(map[copyKey]reflect.Value)}
+	}
+
+	clone := &Container{parent: c.parent}
+	// Children without handlers or observers of their own keep falling back to their parents
+	if h := c.missing.Load(); h != nil {
+		clone.missing.Store(h)
+	}
+	if o := c.observed.Load(); o != nil {
+		clone.observed.Store(o)
+	}
+	c.m.Range(func(ty, names any) bool {
+		inner := clone.getInnerMapOfNames(ty.(reflect.Type))
+		names.(*sync.Map).Range(func(name, value any) bool {
+			if binding, ok := value.(Binding); ok {
+				inner.Store(name, cl.clone(binding))
+			}
+			return true
+		})
+		return true
+	})
+
+	if s := c.sealedBindings(); s != nil {
+		sealed := &sealedBindings{
+			bindings:   make(map[bindingKey]Binding, len(s.bindings)),
+			conditions: make(map[*conditionalBinding][]bool, len(s.conditions)),
+			plans:      make(map[reflect.Type][]Binding, len(s.plans)),
+		}
+		for key, binding := range s.bindings {
+			sealed.bindings[key] = cl.clone(binding)
+		}
+		for cb, results := range s.conditions {
+			sealed.conditions[cl.clone(cb).(*conditionalBinding)] = results
+		}
+		sealed.bindPlans()
+		clone.sealed.Store(sealed)
+	}
+
+	return clone
+}
+
+// cloner copies bindings of a container, making sure each binding gets copied only once.
+type cloner struct {
+	deepCopy bool
+	copier   *copier
+	bindings map[Binding]Binding // Copies of already cloned bindings.
+}
+
+// clone returns a copy of a binding, which does not share any state with the original.
+func (cl *cloner) clone(binding Binding) Binding {
+	if clone, ok := cl.bindings[binding]; ok {
+		return clone
+	}
+
+	var clone Binding
+	switch b := binding.(type) {
+	case *singletonBinding:
+		clone = b.clone(cl.instance)
+	case *instanceBinding:
+		if cl.deepCopy {
+			clone = &instanceBinding{instance: cl.instance(b.instance)}
+		} else {
+			clone = b
+		}
+	case *conditionalBinding:
+		cb := &conditionalBinding{
+			ty:         b.ty,
+			name:       b.name,
+			candidates: make([]conditionalCandidate, len(b.candidates)),
+		}
+		for i, candidate := range b.candidates {
+			cb.candidates[i] = conditionalCandidate{cond: candidate.cond, binding: cl.clone(candidate.binding)}
+		}
+		if b.fallback != nil {
+			cb.fallback = cl.clone(b.fallback)
+		}
+		clone = cb
+	default:
+		// Other bindings do not have any state, so they can be shared
+		clone = binding
+	}
+
+	cl.bindings[binding] = clone
+	return clone
+}
+
+// instance returns the value an existing service should have in the clone.
+func (cl *cloner) instance(v reflect.Value) reflect.Value {
+	if !cl.deepCopy {
+		return v
+	}
+	return cl.copier.copy(v)
+}
+
+// clone returns a copy of the singleton.
+//
+// If the instance has already been built, the copy gets its instance from a provided function.
+func (b *singletonBinding) clone(instance func(reflect.Value) reflect.Value) *singletonBinding {
+	clone := &singletonBinding{
+		implType: b.implType,
+		factory:  b.factory,
+		byValue:  b.byValue,
+	}
+
+	if v, ok := b.current(); ok {
+		clone.instance.Store(&builtInstance{value: instance(v)})
+	}
+
+	return clone
+}
+
+// copyKey identifies memory that has already been copied.
+type copyKey struct {
+	ty  reflect.Type
+	ptr uintptr
+}
+
+// copier makes deep copies of values.
+//
+// Memory reachable from several places gets copied only once,
+// so that the copies refer to each other the same way the originals do.
+type copier struct {
+	seen map[copyKey]reflect.Value
+}
+
+// copy returns a deep copy of a value.
+func (cp *copier) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+
+		key := copyKey{ty: v.Type(), ptr: v.Pointer()}
+		if c, ok := cp.seen[key]; ok {
+			return c
+		}
+
+		c := reflect.New(v.Type().Elem())
+		cp.seen[key] = c
+		c.Elem().Set(cp.copy(v.Elem()))
+		return c
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		c := reflect.New(v.Type()).Elem()
+		c.Set(cp.copy(v.Elem()))
+		return c
+
+	case reflect.Struct:
+		// Unexported fields cannot be set, so they are copied along with the whole struct
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if field := c.Field(i); field.CanSet() {
+				field.Set(cp.copy(v.Field(i)))
+			}
+		}
+		return c
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cp.copy(v.Index(i)))
+		}
+		return c
+
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cp.copy(v.Index(i)))
+		}
+		return c
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+
+		key := copyKey{ty: v.Type(), ptr: v.Pointer()}
+		if c, ok := cp.seen[key]; ok {
+			return c
+		}
+
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		cp.seen[key] = c
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(cp.copy(iter.Key()), cp.copy(iter.Value()))
+		}
+		return c
+
+	default:
+		return v
+	}
+}
@@ -0,0 +1,119 @@
+package dino
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type settings struct {
+	Values  map[string]int
+	Tags    []string
+	Parent  *settings
+	private *settings
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[myInterface1, myStruct1](c))
+
+	clone := c.Clone()
+	assert.Nil(t, Add[*myStruct2, myStruct2](clone))
+	assert.Nil(t, Add[*myStruct1, myStruct1](c))
+
+	_, err := Get[*myStruct2](c)
+	assert.ErrorAs(t, err, &BindingMissingError{})
+	_, err = Get[*myStruct1](clone)
+	assert.ErrorAs(t, err, &BindingMissingError{})
+
+	assert.NotSame(t, MustGet[myInterface1](c), MustGet[myInterface1](clone))
+}
+
+func TestCloneSharesBuiltSingletons(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[*myStruct1, myStruct1](c))
+	assert.Nil(t, Add[*settings, settings](c))
+	built := MustGet[*myStruct1](c)
+
+	clone := c.Clone()
+	assert.Same(t, built, MustGet[*myStruct1](clone))
+	assert.NotSame(t, MustGet[*settings](c), MustGet[*settings](clone))
+}
+
+func TestCloneKeepsSharedBindings(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AddAs[myStruct3](c, As[myInterface1](), As[myInterface2]()))
+
+	clone := c.Clone()
+	svc := MustGet[*myStruct3](clone)
+	assert.Same(t, svc, MustGet[myInterface1](clone))
+	assert.Same(t, svc, MustGet[myInterface2](clone))
+	assert.NotSame(t, svc, MustGet[*myStruct3](c))
+}
+
+func TestCloneDeepCopiesInstances(t *testing.T) {
+	root := &settings{Values: map[string]int{"a": 1}, Tags: []string{"x"}}
+	root.Parent = root
+	root.private = root
+
+	c := &Container{}
+	assert.Nil(t, AddInstance[*settings](c, root))
+
+	shared := MustGet[*settings](c.Clone(ShareInstances()))
+	assert.Same(t, root, shared)
+
+	copied := MustGet[*settings](c.Clone(DeepCopyInstances()))
+	assert.NotSame(t, root, copied)
+	assert.Equal(t, root.Values, copied.Values)
+	assert.Equal(t, root.Tags, copied.Tags)
+	assert.Same(t, copied, copied.Parent)
+	assert.Same(t, root, copied.private)
+
+	copied.Values["a"] = 2
+	copied.Tags[0] = "y"
+	assert.Equal(t, 1, root.Values["a"])
+	assert.Equal(t, "x", root.Tags[0])
+}
+
+func TestCloneDeepCopiesBuiltSingletons(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[clock, realClock](c))
+	assert.Nil(t, Add[*scheduler, scheduler](c))
+	assert.Nil(t, Add[*app, app](c))
+	original := MustGet[*app](c)
+
+	clone := c.Clone(DeepCopyInstances())
+	copied := MustGet[*app](clone)
+	assert.NotSame(t, original, copied)
+	assert.NotSame(t, original.Scheduler, copied.Scheduler)
+	assert.Same(t, copied.Scheduler, MustGet[*scheduler](clone))
+}
+
+func TestCloneSealed(t *testing.T) {
+	t.Setenv(ProfileEnv, "dev")
+
+	c := &Container{}
+	assert.Nil(t, Add[mailSender, realMailSender](c))
+	assert.Nil(t, AddIf(c, Profile("dev"), func(c *Container) error {
+		return Add[mailSender, fakeMailSender](c)
+	}))
+	assert.Nil(t, Add[*settings, settings](c))
+	assert.Nil(t, c.Seal())
+
+	t.Setenv(ProfileEnv, "prod")
+	clone := c.Clone()
+	assert.True(t, clone.IsSealed())
+	assert.ErrorAs(t, Add[*myStruct1, myStruct1](clone), &ContainerSealedError{})
+
+	_, isFake := MustGet[mailSender](clone).(*fakeMailSender)
+	assert.True(t, isFake)
+	assert.NotSame(t, MustGet[*settings](c), MustGet[*settings](clone))
+	conditional := 0
+	for _, info := range clone.Bindings() {
+		if info.Kind == "conditional" {
+			conditional++
+			assert.Contains(t, info.String(), "(active)")
+		}
+	}
+	assert.Equal(t, 1, conditional)
+}
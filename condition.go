@@ -0,0 +1,314 @@
+package dino
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ProfileEnv is the name of an environment variable
+// that selects the active build profile for Profile conditions.
+const ProfileEnv = "DINO_PROFILE"
+
+// Condition decides whether a conditional registration is active.
+type Condition struct {
+	desc string
+	eval func(c *Container, seen map[*conditionalBinding]bool) bool
+}
+
+func (cond Condition) String() string {
+	return cond.desc
+}
+
+// When creates a condition that is met when the predicate returns true.
+//
+// The description is only used to describe the condition during introspection.
+// The predicate gets called once when the container is sealed, but before that,
+// it gets called on every resolution of the services registered under the condition.
+func When(desc string, predicate func() bool) Condition {
+	return Condition{
+		desc: desc,
+		eval: func(_ *Container, _ map[*conditionalBinding]bool) bool {
+			return predicate()
+		},
+	}
+}
+
+// EnvSet creates a condition that is met when an environment variable is set.
+func EnvSet(key string) Condition {
+	return When("env "+key+" is set", func() bool {
+		_, ok := os.LookupEnv(key)
+		return ok
+	})
+}
+
+// EnvEquals creates a condition that is met when an environment variable has a provided value.
+func EnvEquals(key string, value string) Condition {
+	return When("env "+key+"="+value, func() bool {
+		return os.Getenv(key) == value
+	})
+}
+
+// Profile creates a condition that is met when a provided build profile is active.
+//
+// The active profile is read from the environment variable named by ProfileEnv.
+func Profile(name string) Condition {
+	return When("profile "+name, func() bool {
+		return os.Getenv(ProfileEnv) == name
+	})
+}
+
+// Present creates a condition that is met when the container
+// has an active binding of type T under a provided namespace.
+func Present[T any](name string) Condition {
+	ty := getType[T]()
+	return Condition{
+		desc: formatKey(ty, name) + " is present",
+		eval: func(c *Container, seen map[*conditionalBinding]bool) bool {
+			return c.hasActive(ty, name, seen)
+		},
+	}
+}
+
+// Missing creates a condition that is met when the container
+// does not have an active binding of type T under a provided namespace.
+func Missing[T any](name string) Condition {
+	ty := getType[T]()
+	return Condition{
+		desc: formatKey(ty, name) + " is missing",
+		eval: func(c *Container, seen map[*conditionalBinding]bool) bool {
+			return !c.hasActive(ty, name, seen)
+		},
+	}
+}
+
+// Not creates a condition that is met when the provided one is not.
+func Not(cond Condition) Condition {
+	return Condition{
+		desc: "not (" + cond.desc + ")",
+		eval: func(c *Container, seen map[*conditionalBinding]bool) bool {
+			return !cond.eval(c, seen)
+		},
+	}
+}
+
+// AddIf registers services only if the provided condition is met.
+//
+// The registration function should register the services into the container passed to it.
+// Conditions are evaluated once when the container gets sealed, so the same type and name
+// can be registered several times under different conditions.
+// Until then, they are evaluated again each time the services are requested or introspected,
+// so predicates should be cheap and return the same results.
+// If more than one condition is met, the registration made last wins.
+// If none is met, the container falls back to an unconditional registration made earlier, if any.
+func AddIf(c *Container, cond Condition, register func(c *Container) error) error {
+	scratch := &Container{}
+	if err := register(scratch); err != nil {
+		return err
+	}
+
+	var err error
+	scratch.m.Range(func(key, value any) bool {
+		ty := key.(reflect.Type)
+		value.(*sync.Map).Range(func(key, value any) bool {
+			if binding, ok := value.(Binding); ok {
+				err = c.storeConditional(ty, key.(string), cond, binding)
+			}
+			return err == nil
+		})
+		return err == nil
+	})
+
+	return err
+}
+
+// storeConditional stores the Binding for a provided type and name,
+// which will only be used when the condition is met.
+func (c *Container) storeConditional(ty reflect.Type, name string, cond Condition, binding Binding) error {
+	c = c.unwrap()
+	c.registering.Lock()
+	defer c.registering.Unlock()
+
+	cb := &conditionalBinding{ty: ty, name: name}
+	if existing, ok := c.getInnerMapOfNames(ty).Load(name); ok {
+		if prev, ok := existing.(*conditionalBinding); ok {
+			cb.candidates = append(cb.candidates, prev.candidates...)
+			cb.fallback = prev.fallback
+		} else {
+			cb.fallback, _ = existing.(Binding)
+		}
+	}
+
+	cb.candidates = append(cb.candidates, conditionalCandidate{cond: cond, binding: binding})
+	return c.storeLocked(ty, name, cb)
+}
+
+// hasActive checks whether the container has a binding for a provided type and name,
+// whose condition (if any) is currently met.
+func (c *Container) hasActive(ty reflect.Type, name string, seen map[*conditionalBinding]bool) bool {
+	v, ok := c.getInnerMapOfNames(ty).Load(name)
+	if !ok {
+		return false
+	}
+
+	if cb, ok := v.(*conditionalBinding); ok {
+		return cb.active(c, seen) != nil
+	}
+
+	_, ok = v.(Binding)
+	return ok
+}
+
+// conditionalCandidate is a binding registered under a condition.
+type conditionalCandidate struct {
+	cond    Condition
+	binding Binding
+}
+
+// conditionalBinding describes a service that is provided by one of the candidate bindings,
+// depending on which of their conditions are met.
+type conditionalBinding struct {
+	ty         reflect.Type
+	name       string
+	candidates []conditionalCandidate // Candidates registered later come last.
+	fallback   Binding                // Binding registered unconditionally before, if any.
+}
+
+// active returns the binding that should currently be used, or nil if there is none.
+//
+// Seen contains conditional bindings that are currently being evaluated,
+// so that a condition can refer to the binding it is attached to.
+// In such case, only the fallback of the binding is considered.
+func (b *conditionalBinding) active(c *Container, seen map[*conditionalBinding]bool) Binding {
+	if seen[b] {
+		return b.fallback
+	}
+
+	if seen == nil {
+		seen = make(map[*conditionalBinding]bool)
+	}
+	seen[b] = true
+	defer delete(seen, b)
+
+	for i := len(b.candidates) - 1; i >= 0; i-- {
+		candidate := b.candidates[i]
+		if !candidate.cond.eval(c, seen) {
+			continue
+		}
+
+		// Conditional registrations might be nested
+		if inner, ok := candidate.binding.(*conditionalBinding); ok {
+			if binding := inner.active(c, seen); binding != nil {
+				return binding
+			}
+			continue
+		}
+
+		return candidate.binding
+	}
+
+	return b.fallback
+}
+
+// evaluate returns results of conditions of all the candidates.
+func (b *conditionalBinding) evaluate(c *Container) []bool {
+	results := make([]bool, len(b.candidates))
+	for i, candidate := range b.candidates {
+		results[i] = candidate.cond.eval(c, map[*conditionalBinding]bool{b: true})
+	}
+	return results
+}
+
+// evaluateInto records results of conditions of the binding and of the conditional bindings nested in it,
+// unless they have already been recorded.
+func (b *conditionalBinding) evaluateInto(c *Container, results map[*conditionalBinding][]bool) {
+	if _, ok := results[b]; !ok {
+		results[b] = b.evaluate(c)
+	}
+	for _, candidate := range b.candidates {
+		if inner, ok := candidate.binding.(*conditionalBinding); ok {
+			inner.evaluateInto(c, results)
+		}
+	}
+}
+
+// recordInto copies recorded results of conditions of the binding and of the conditional bindings nested in it.
+func (b *conditionalBinding) recordInto(results map[*conditionalBinding][]bool, to map[*conditionalBinding][]bool) {
+	to[b] = results[b]
+	for _, candidate := range b.candidates {
+		if inner, ok := candidate.binding.(*conditionalBinding); ok {
+			inner.recordInto(results, to)
+		}
+	}
+}
+
+// chosen returns the binding selected by recorded results of conditions, the same way active does,
+// or nil if there is none. If some of the results have not been recorded, it returns false.
+func (b *conditionalBinding) chosen(results map[*conditionalBinding][]bool) (Binding, bool) {
+	met, ok := results[b]
+	if !ok {
+		return nil, false
+	}
+
+	for i := len(b.candidates) - 1; i >= 0; i-- {
+		if !met[i] {
+			continue
+		}
+
+		candidate := b.candidates[i]
+		if inner, ok := candidate.binding.(*conditionalBinding); ok {
+			binding, ok := inner.chosen(results)
+			if !ok {
+				return nil, false
+			} else if binding != nil {
+				return binding, true
+			}
+			continue
+		}
+
+		return candidate.binding, true
+	}
+
+	return b.fallback, true
+}
+
+func (b *conditionalBinding) Provide(c *Container, chain []DepLink) (svc reflect.Value, err error) {
+	active := b.active(c, nil)
+	if active == nil {
+		err = c.bindingMissing(b.ty, b.name)
+		return
+	}
+
+	// The chain describes the binding which actually provides the service,
+	// so that eg. singletons being built can be found in it
+	if len(chain) > 0 {
+		chain[len(chain)-1].binding = active
+	}
+	return active.Provide(c, chain)
+}
+
+func (b *conditionalBinding) describe(c *Container) string {
+	// Once the container is sealed, conditions no longer get evaluated
+	var results []bool
+	if s := c.sealedBindings(); s != nil {
+		results = s.conditions[b]
+	} else {
+		results = b.evaluate(c)
+	}
+
+	parts := make([]string, 0, len(b.candidates)+1)
+	for i, candidate := range b.candidates {
+		state := "inactive"
+		if i < len(results) && results[i] {
+			state = "active"
+		}
+		parts = append(parts, "if "+candidate.cond.desc+" ("+state+"): "+describeBinding(c, candidate.binding))
+	}
+
+	if b.fallback != nil {
+		parts = append(parts, "otherwise: "+describeBinding(c, b.fallback))
+	}
+
+	return strings.Join(parts, "; ")
+}
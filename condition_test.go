@@ -0,0 +1,122 @@
+package dino
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mailSender interface {
+	Send(to string) string
+}
+
+type fakeMailSender struct{}
+
+func (s *fakeMailSender) Send(to string) string { return "fake:" + to }
+
+type realMailSender struct{}
+
+func (s *realMailSender) Send(to string) string { return "real:" + to }
+
+func TestAddIfSelectsActiveRegistration(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[mailSender, realMailSender](c))
+	assert.Nil(t, AddIf(c, Profile("dev"), func(c *Container) error {
+		return Add[mailSender, fakeMailSender](c)
+	}))
+
+	t.Setenv(ProfileEnv, "prod")
+	sender, err := Get[mailSender](c)
+	assert.Nil(t, err)
+	assert.Equal(t, "real:me", sender.Send("me"))
+
+	t.Setenv(ProfileEnv, "dev")
+	sender, err = Get[mailSender](c)
+	assert.Nil(t, err)
+	assert.Equal(t, "fake:me", sender.Send("me"))
+}
+
+func TestAddIfLastActiveWins(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AddIf(c, EnvSet("DINO_TEST_FAKE"), func(c *Container) error {
+		return Add[mailSender, fakeMailSender](c)
+	}))
+	assert.Nil(t, AddIf(c, EnvEquals("DINO_TEST_REAL", "1"), func(c *Container) error {
+		return Add[mailSender, realMailSender](c)
+	}))
+
+	_, err := Get[mailSender](c)
+	assert.ErrorAs(t, err, &BindingMissingError{})
+
+	t.Setenv("DINO_TEST_FAKE", "")
+	sender, err := Get[mailSender](c)
+	assert.Nil(t, err)
+	assert.Equal(t, "fake:me", sender.Send("me"))
+
+	t.Setenv("DINO_TEST_REAL", "1")
+	sender, err = Get[mailSender](c)
+	assert.Nil(t, err)
+	assert.Equal(t, "real:me", sender.Send("me"))
+}
+
+func TestAddIfMissingFallsBackToSelf(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AddIf(c, Missing[mailSender](""), func(c *Container) error {
+		return Add[mailSender, fakeMailSender](c)
+	}))
+
+	sender, err := Get[mailSender](c)
+	assert.Nil(t, err)
+	assert.Equal(t, "fake:me", sender.Send("me"))
+
+	assert.Nil(t, Add[mailSender, realMailSender](c))
+	sender, err = Get[mailSender](c)
+	assert.Nil(t, err)
+	assert.Equal(t, "real:me", sender.Send("me"))
+}
+
+func TestAddIfDependsOnOtherBindings(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AddIf(c, Present[*realMailSender](""), func(c *Container) error {
+		return Alias[mailSender, *realMailSender](c)
+	}))
+	assert.Nil(t, AddIf(c, Not(When("always", func() bool { return true })), func(c *Container) error {
+		return Add[*realMailSender, realMailSender](c)
+	}))
+
+	_, err := Get[mailSender](c)
+	assert.ErrorAs(t, err, &BindingMissingError{})
+
+	assert.Nil(t, Add[*realMailSender, realMailSender](c))
+	sender, err := Get[mailSender](c)
+	assert.Nil(t, err)
+	assert.Equal(t, "real:me", sender.Send("me"))
+}
+
+func TestAddIfReturnsRegistrationErrors(t *testing.T) {
+	c := &Container{}
+	myErr := errors.New("registration failed")
+	err := AddIf(c, Profile("dev"), func(c *Container) error {
+		assert.Nil(t, Add[mailSender, fakeMailSender](c))
+		return myErr
+	})
+
+	assert.ErrorIs(t, err, myErr)
+	assert.Empty(t, c.Bindings())
+}
+
+func TestConditionalBindingsAreDescribed(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[mailSender, realMailSender](c))
+	assert.Nil(t, AddIf(c, Profile("dev"), func(c *Container) error {
+		return Add[mailSender, fakeMailSender](c)
+	}))
+
+	t.Setenv(ProfileEnv, "dev")
+	infos := c.Bindings()
+	assert.Len(t, infos, 1)
+	assert.Equal(t, "conditional", infos[0].Kind)
+	assert.Equal(t, "if profile dev (active): [singleton] as *dino.fakeMailSender; "+
+		"otherwise: [singleton] as *dino.realMailSender", infos[0].Details)
+}
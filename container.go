@@ -1,14 +1,23 @@
 package dino
 
 import (
+	"errors"
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 // Container stores maps between abstractions and concrete implementations.
 type Container struct {
-	m sync.Map
+	m           sync.Map
+	registering sync.Mutex      // Serializes registrations with sealing.
+	sealed      atomic.Value    // Holds *sealedBindings once the container gets sealed.
+	missing     atomic.Value    // Holds a missingHolder, if a MissingHandler has been set.
+	observed    atomic.Value    // Holds an observerHolder, if an Observer has been set.
+	parent      *Container      // Container to fall back to, if this one was created with NewChild.
+	built       builtSingletons // Singletons built by the container, to be disposed of by Close.
+	call        *factoryCall    // Set for containers handed to factories, which stand for their parents.
 }
 
 // getInnerMapOfNames gets a map of names to bindings.
@@ -31,7 +40,7 @@ func Get[T any](c *Container) (svc T, err error) {
 // GetNamed tries to create, retrieve or inject an object of type T.
 func GetNamed[T any](c *Container, name string) (svc T, err error) {
 	ty := getType[T]()
-	s, err := c.tryGet(ty, name, make([]DepLink, 0, 4))
+	s, err := c.tryGet(ty, name, c.newChain())
 	if err != nil {
 		return
 	}
@@ -41,39 +50,95 @@ func GetNamed[T any](c *Container, name string) (svc T, err error) {
 		err = InvalidTypeError{
 			name:     name,
 			expected: ty,
-			actual:   reflect.TypeOf(s),
+			actual:   s.Type(),
 		}
 	}
 
 	return
 }
 
-// tryGets attempts to retrieve a service in a ready state from the container.
+// GetValue tries to create, retrieve or inject an object of a type known only at runtime.
+//
+// The value has the provided type, even if it is an interface.
+func GetValue(c *Container, ty reflect.Type, name string) (reflect.Value, error) {
+	s, err := c.tryGet(ty, name, c.newChain())
+	if err != nil || !s.IsValid() || s.Type() == ty {
+		return s, err
+	}
+
+	if !s.Type().AssignableTo(ty) {
+		return reflect.Value{}, InvalidTypeError{name: name, expected: ty, actual: s.Type()}
+	}
+	svc := reflect.New(ty).Elem()
+	svc.Set(s)
+	return svc, nil
+}
+
+// tryGet attempts to retrieve a service in a ready state from the container.
 func (c *Container) tryGet(ty reflect.Type, name string, chain []DepLink) (reflect.Value, error) {
-	b, ok := c.tryLoad(ty, name)
+	b, owner, ok := c.lookup(ty, name)
 	if !ok {
-		return reflect.Value{}, BindingMissingError{ty: ty, name: name}
+		return reflect.Value{}, c.bindingMissing(ty, name)
 	}
 
-	chain = append(chain, DepLink{ty: ty, binding: b})
-	svc, err := b.Provide(c, chain)
-	return svc, err
+	return owner.provide(ty, name, b, chain)
 }
 
-// tryLoad attempts to retrieve the Binding for a provided type and name.
+// provide asks a binding to provide a service requested as a provided type and name.
+func (c *Container) provide(ty reflect.Type, name string, b Binding, chain []DepLink) (reflect.Value, error) {
+	if o := c.observer(); o != nil {
+		return c.provideObserved(o, ty, name, b, chain)
+	}
+
+	chain = append(chain, DepLink{ty: ty, name: name, binding: b})
+	return b.Provide(c, chain)
+}
+
+// tryLoad attempts to retrieve the Binding for a provided type and name,
+// without looking at parent containers.
+//
+// If the binding was registered conditionally, the currently active one is returned.
 func (c *Container) tryLoad(ty reflect.Type, name string) (b Binding, ok bool) {
+	if s := c.sealedBindings(); s != nil {
+		b, ok = s.bindings[bindingKey{ty: ty, name: name}]
+		return
+	}
+
 	v, ok := c.getInnerMapOfNames(ty).Load(name)
 	if ok {
 		b, ok = v.(Binding)
 	}
+	if cb, isConditional := b.(*conditionalBinding); ok && isConditional {
+		b = cb.active(c, nil)
+		ok = b != nil
+	}
 	return
 }
 
 // store stores the Binding for a provided type and name, replacing all previous values.
-func (c *Container) store(ty reflect.Type, name string, binding Binding) {
+//
+// It fails if the container has already been sealed.
+func (c *Container) store(ty reflect.Type, name string, binding Binding) error {
+	c = c.unwrap()
+	c.registering.Lock()
+	defer c.registering.Unlock()
+	return c.storeLocked(ty, name, binding)
+}
+
+// storeLocked stores the Binding for a provided type and name,
+// while the caller holds the registration lock.
+func (c *Container) storeLocked(ty reflect.Type, name string, binding Binding) error {
+	if c.IsSealed() {
+		return ContainerSealedError{ty: ty, name: name}
+	}
+
 	c.getInnerMapOfNames(ty).Store(name, binding)
+	return nil
 }
 
+// ErrInvalidType matches InvalidTypeError with errors.Is.
+var ErrInvalidType = errors.New("binding provided an object of invalid type")
+
 // InvalidTypeError occurs when a binding is present,
 // but it does not implement the requested abstraction.
 type InvalidTypeError struct {
@@ -82,6 +147,25 @@ type InvalidTypeError struct {
 	actual   reflect.Type
 }
 
+// Name returns the namespace the type was requested from.
+func (e InvalidTypeError) Name() string {
+	return e.name
+}
+
+// Expected returns the type requested from the container.
+func (e InvalidTypeError) Expected() reflect.Type {
+	return e.expected
+}
+
+// Actual returns the type of the object the binding provided.
+func (e InvalidTypeError) Actual() reflect.Type {
+	return e.actual
+}
+
+func (e InvalidTypeError) Is(target error) bool {
+	return target == ErrInvalidType
+}
+
 func (e InvalidTypeError) Error() string {
 	var b strings.Builder
 	b.WriteString("container had stored a binding for type ")
@@ -93,11 +177,45 @@ func (e InvalidTypeError) Error() string {
 	return b.String()
 }
 
+// ErrBindingMissing matches BindingMissingError with errors.Is.
+var ErrBindingMissing = errors.New("binding missing")
+
 // BindingMissingError happens when a container does not have binding information
 // about a provided type-name pair.
+//
+// Its message suggests similar bindings registered in the container, if there are any.
 type BindingMissingError struct {
-	ty   reflect.Type
-	name string
+	ty          reflect.Type
+	name        string
+	suggestions []string
+}
+
+// bindingMissing creates a BindingMissingError, along with suggestions of bindings
+// registered in the container at the time.
+func (c *Container) bindingMissing(ty reflect.Type, name string) BindingMissingError {
+	return BindingMissingError{ty: ty, name: name, suggestions: c.suggest(ty, name)}
+}
+
+// Type returns the type requested from the container.
+func (e BindingMissingError) Type() reflect.Type {
+	return e.ty
+}
+
+// Name returns the namespace the type was requested from.
+func (e BindingMissingError) Name() string {
+	return e.name
+}
+
+// Suggestions returns descriptions of registered bindings that might have been meant instead,
+// eg. the same type under a different name, best matches first.
+//
+// They are looked up once, when the error occurs, so later registrations do not change them.
+func (e BindingMissingError) Suggestions() []string {
+	return e.suggestions
+}
+
+func (e BindingMissingError) Is(target error) bool {
+	return target == ErrBindingMissing
 }
 
 func (e BindingMissingError) Error() string {
@@ -111,6 +229,7 @@ func (e BindingMissingError) Error() string {
 		b.WriteString(e.name)
 		b.WriteString("\"")
 	}
+	b.WriteString(formatSuggestions(e.suggestions))
 	return b.String()
 
 }
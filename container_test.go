@@ -60,6 +60,20 @@ func TestEmptyContainerErrors(t *testing.T) {
 	})
 }
 
+func TestBindingMissingErrorIsInspectable(t *testing.T) {
+	type foo struct{}
+	c := &Container{}
+
+	_, err := GetNamed[*foo](c, "foobar")
+	assert.ErrorIs(t, err, ErrBindingMissing)
+	assert.NotErrorIs(t, err, ErrInvalidType)
+
+	var missing BindingMissingError
+	assert.ErrorAs(t, err, &missing)
+	assert.Equal(t, getType[*foo](), missing.Type())
+	assert.Equal(t, "foobar", missing.Name())
+}
+
 func TestContainerGetsConstructedSingletons(t *testing.T) {
 	type foo struct {
 		bar int
@@ -83,6 +97,19 @@ func TestContainerGetsConstructedSingletons(t *testing.T) {
 	assert.Equal(t, 4, myFoo2.bar)
 }
 
+func TestGetValueResolvesRuntimeTypes(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AddNamed[mailSender, realMailSender](c, "smtp"))
+
+	svc, err := GetValue(c, getType[mailSender](), "smtp")
+	assert.Nil(t, err)
+	assert.Equal(t, getType[mailSender](), svc.Type())
+	assert.Equal(t, "real:bob", svc.Interface().(mailSender).Send("bob"))
+
+	_, err = GetValue(c, getType[mailSender](), "")
+	assert.ErrorIs(t, err, ErrBindingMissing)
+}
+
 func TestWrongBindingTypeErrors(t *testing.T) {
 	type foo struct{}
 	type bar struct{}
@@ -97,4 +124,11 @@ func TestWrongBindingTypeErrors(t *testing.T) {
 		assert.NotContains(t, "global", err.Error())
 	})
 
+	var invalid InvalidTypeError
+	assert.ErrorAs(t, err, &invalid)
+	assert.ErrorIs(t, err, ErrInvalidType)
+	assert.Equal(t, "name", invalid.Name())
+	assert.Equal(t, getType[*foo](), invalid.Expected())
+	assert.Equal(t, getType[*bar](), invalid.Actual())
+
 }
@@ -0,0 +1,162 @@
+// Package dinohttp integrates Dino containers with net/http.
+package dinohttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/frixuu/dino"
+)
+
+// ErrNoScope is returned when a context does not carry a request scope.
+var ErrNoScope = errors.New("dinohttp: context does not carry a request scope")
+
+// scopeKey is the key of the request scope in a context.
+type scopeKey struct{}
+
+// WithScope returns a copy of a context carrying a request scope.
+func WithScope(ctx context.Context, scope *dino.Container) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// Scope returns the request scope carried by a context, if there is one.
+func Scope(ctx context.Context) (*dino.Container, bool) {
+	scope, ok := ctx.Value(scopeKey{}).(*dino.Container)
+	return scope, ok && scope != nil
+}
+
+// FromContext tries to create, retrieve or inject an object of type T
+// from the request scope carried by a context.
+func FromContext[T any](ctx context.Context) (svc T, err error) {
+	return FromContextNamed[T](ctx, "")
+}
+
+// FromContextNamed tries to create, retrieve or inject an object of type T
+// from the request scope carried by a context.
+func FromContextNamed[T any](ctx context.Context, name string) (svc T, err error) {
+	scope, ok := Scope(ctx)
+	if !ok {
+		err = ErrNoScope
+		return
+	}
+	return dino.GetNamed[T](scope, name)
+}
+
+// MustFromContext tries to create, retrieve or inject an object of type T
+// from the request scope carried by a context.
+//
+// If the operation fails, this method will panic.
+func MustFromContext[T any](ctx context.Context) T {
+	svc, err := FromContext[T](ctx)
+	if err != nil {
+		panic(err)
+	}
+	return svc
+}
+
+// Option configures Middleware and handlers created with Handle.
+type Option struct {
+	apply func(m *middleware)
+}
+
+// Setup makes the middleware call a function registering request-scoped services,
+// eg. the current user, in each new scope. Options are applied in order,
+// so several setup functions get called in the order they were provided.
+func Setup(setup func(r *http.Request, scope *dino.Container) error) Option {
+	return Option{apply: func(m *middleware) {
+		m.setups = append(m.setups, setup)
+	}}
+}
+
+// OnError sets a function responding to requests, for which a request scope could not be set up
+// or a handler could not be resolved. By default, such requests get a 500 Internal Server Error response.
+func OnError(handle func(w http.ResponseWriter, r *http.Request, err error)) Option {
+	return Option{apply: func(m *middleware) {
+		m.onError = handle
+	}}
+}
+
+// OnCloseError sets a function that gets notified when a request scope fails to be disposed of.
+// The response has already been written at that point, so it should only report the error.
+func OnCloseError(handle func(r *http.Request, err error)) Option {
+	return Option{apply: func(m *middleware) {
+		m.onCloseError = handle
+	}}
+}
+
+// middleware describes how to create request scopes.
+type middleware struct {
+	root         *dino.Container
+	setups       []func(r *http.Request, scope *dino.Container) error
+	onError      func(w http.ResponseWriter, r *http.Request, err error)
+	onCloseError func(r *http.Request, err error)
+}
+
+// Middleware returns a middleware that creates a request scope for each request,
+// puts it in the context of the request and disposes of it once the request is handled.
+//
+// A request scope is a child container of the root one, so request-scoped services registered in it
+// can depend on all the services of the root container, but not the other way round.
+// Each scope has the request registered as *http.Request, with the scope already in its context.
+// Singletons registered in a scope live as long as the request, and get closed afterwards,
+// as described by Container.Close.
+func Middleware(root *dino.Container, opts ...Option) func(next http.Handler) http.Handler {
+	m := newMiddleware(root, opts)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := m.root.NewChild()
+			defer m.close(r, scope)
+
+			r = r.WithContext(WithScope(r.Context(), scope))
+			if err := m.setup(r, scope); err != nil {
+				m.onError(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// newMiddleware applies options to the default configuration.
+func newMiddleware(root *dino.Container, opts []Option) *middleware {
+	m := &middleware{
+		root: root,
+		onError: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		},
+	}
+	for _, opt := range opts {
+		opt.apply(m)
+	}
+	return m
+}
+
+// scope returns the container services of a request should be resolved from.
+func (m *middleware) scope(r *http.Request) *dino.Container {
+	if scope, ok := Scope(r.Context()); ok {
+		return scope
+	}
+	return m.root
+}
+
+// setup registers request-scoped services in a new scope.
+func (m *middleware) setup(r *http.Request, scope *dino.Container) error {
+	if err := dino.AddInstance[*http.Request](scope, r); err != nil {
+		return err
+	}
+	for _, setup := range m.setups {
+		if err := setup(r, scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close disposes of a request scope.
+func (m *middleware) close(r *http.Request, scope *dino.Container) {
+	if err := scope.Close(); err != nil && m.onCloseError != nil {
+		m.onCloseError(r, err)
+	}
+}
@@ -0,0 +1,139 @@
+package dinohttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/frixuu/dino"
+	"github.com/stretchr/testify/assert"
+)
+
+type repo struct{}
+
+type user struct {
+	Name string
+}
+
+type tx struct {
+	Repo   *repo
+	closed bool
+}
+
+func (t *tx) Close() error {
+	t.closed = true
+	return nil
+}
+
+func newRoot() *dino.Container {
+	c := &dino.Container{}
+	dino.MustAdd[*repo, repo](c)
+	return c
+}
+
+func withUser(r *http.Request, scope *dino.Container) error {
+	return dino.AddFactory(scope, func(c *dino.Container) (*user, error) {
+		req := dino.MustGet[*http.Request](c)
+		return &user{Name: req.Header.Get("X-User")}, nil
+	})
+}
+
+func withTx(r *http.Request, scope *dino.Container) error {
+	return dino.Add[*tx, tx](scope)
+}
+
+func TestMiddlewareProvidesRequestScope(t *testing.T) {
+	root := newRoot()
+
+	var first, second *tx
+	handler := Middleware(root, Setup(withUser), Setup(withTx))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u := MustFromContext[*user](r.Context())
+		current, err := FromContext[*tx](r.Context())
+		assert.Nil(t, err)
+		assert.Same(t, current, MustFromContext[*tx](r.Context()))
+		assert.Same(t, dino.MustGet[*repo](root), current.Repo)
+
+		if first == nil {
+			first = current
+		} else {
+			second = current
+		}
+		_, _ = w.Write([]byte("hello " + u.Name))
+	}))
+
+	for _, name := range []string{"alice", "bob"} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-User", name)
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, "hello "+name, rec.Body.String())
+	}
+
+	// Each request gets its own scope, which is disposed of afterwards
+	assert.NotSame(t, first, second)
+	assert.True(t, first.closed)
+	assert.True(t, second.closed)
+
+	_, err := dino.Get[*tx](root)
+	assert.ErrorIs(t, err, dino.ErrBindingMissing)
+}
+
+func TestMiddlewareReportsSetupErrors(t *testing.T) {
+	failing := Setup(func(r *http.Request, scope *dino.Container) error {
+		return errors.New("no database")
+	})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler should not be called")
+	})
+
+	rec := httptest.NewRecorder()
+	Middleware(newRoot(), failing)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	rec = httptest.NewRecorder()
+	onError := OnError(func(w http.ResponseWriter, r *http.Request, err error) {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	})
+	Middleware(newRoot(), failing, onError)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "no database\n", rec.Body.String())
+}
+
+type failingCloser struct{}
+
+func (failingCloser) Close() error {
+	return errors.New("rollback failed")
+}
+
+func TestMiddlewareReportsCloseErrors(t *testing.T) {
+	var closeErr error
+	handler := Middleware(newRoot(),
+		Setup(func(r *http.Request, scope *dino.Container) error {
+			return dino.Add[*failingCloser, failingCloser](scope)
+		}),
+		OnCloseError(func(r *http.Request, err error) {
+			closeErr = err
+		}),
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		MustFromContext[*failingCloser](r.Context())
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.EqualError(t, closeErr, "rollback failed")
+}
+
+func TestFromContextWithoutScope(t *testing.T) {
+	_, err := FromContext[*repo](context.Background())
+	assert.ErrorIs(t, err, ErrNoScope)
+	assert.Panics(t, func() { MustFromContext[*repo](context.Background()) })
+
+	_, ok := Scope(context.Background())
+	assert.False(t, ok)
+
+	c := newRoot()
+	svc, err := FromContext[*repo](WithScope(context.Background(), c))
+	assert.Nil(t, err)
+	assert.Same(t, dino.MustGet[*repo](c), svc)
+}
@@ -0,0 +1,119 @@
+package dinohttp
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/frixuu/dino"
+)
+
+var (
+	responseWriterType = reflect.TypeOf((*http.ResponseWriter)(nil)).Elem()
+	requestType        = reflect.TypeOf((*http.Request)(nil))
+	errorType          = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// handlerMethods are names of methods that can handle requests, in the order of preference.
+var handlerMethods = []string{"ServeHTTP", "Handle"}
+
+// Handle returns a handler that resolves a service of type H in a global namespace for each request
+// and lets it handle the request. H should usually be registered as a transient.
+//
+// See HandleNamed for details.
+func Handle[H any](c *dino.Container, opts ...Option) http.Handler {
+	return HandleNamed[H](c, "", opts...)
+}
+
+// HandleNamed returns a handler that resolves a service of type H under a provided namespace
+// for each request and lets it handle the request. H should usually be registered as a transient.
+//
+// The service gets resolved from the request scope, if the request has gone through Middleware,
+// or from the provided container otherwise.
+//
+// If H implements http.Handler, its ServeHTTP method gets called.
+// Otherwise, H must have a ServeHTTP or Handle method accepting an http.ResponseWriter
+// and an *http.Request, followed by any number of services, which get resolved from the same
+// container (in a global namespace). The method can return an error.
+// Errors of resolving the services or returned by the method are passed to the OnError handler,
+// which responds with 500 Internal Server Error by default. Other options are ignored.
+//
+// If H does not have such a method, HandleNamed panics.
+func HandleNamed[H any](c *dino.Container, name string, opts ...Option) http.Handler {
+	m := newMiddleware(c, opts)
+	ty := reflect.TypeOf((*H)(nil)).Elem()
+	if ty.Implements(reflect.TypeOf((*http.Handler)(nil)).Elem()) {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h, err := dino.GetNamed[H](m.scope(r), name)
+			if err != nil {
+				m.onError(w, r, err)
+				return
+			}
+			any(h).(http.Handler).ServeHTTP(w, r)
+		})
+	}
+
+	method, params, ok := findHandlerMethod(ty)
+	if !ok {
+		panic(fmt.Sprintf("dinohttp: %s has no ServeHTTP or Handle method accepting "+
+			"(http.ResponseWriter, *http.Request, ...)", ty))
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope := m.scope(r)
+		h, err := dino.GetValue(scope, ty, name)
+		if err != nil {
+			m.onError(w, r, err)
+			return
+		}
+
+		args := make([]reflect.Value, 2, 2+len(params))
+		args[0], args[1] = reflect.ValueOf(w), reflect.ValueOf(r)
+		for _, param := range params {
+			arg, err := dino.GetValue(scope, param, "")
+			if err != nil {
+				m.onError(w, r, err)
+				return
+			}
+			args = append(args, arg)
+		}
+
+		results := h.MethodByName(method).Call(args)
+		if len(results) > 0 && !results[0].IsNil() {
+			m.onError(w, r, results[0].Interface().(error))
+		}
+	})
+}
+
+// findHandlerMethod looks for a method of a type that can handle requests
+// and returns its name and the types of services it needs besides the response writer and the request.
+func findHandlerMethod(ty reflect.Type) (name string, params []reflect.Type, ok bool) {
+	for _, name := range handlerMethods {
+		m, ok := ty.MethodByName(name)
+		if !ok {
+			continue
+		}
+
+		// Methods of interfaces do not have receivers
+		fn := m.Type
+		first := 1
+		if ty.Kind() == reflect.Interface {
+			first = 0
+		}
+
+		if fn.IsVariadic() || fn.NumIn() < first+2 ||
+			fn.In(first) != responseWriterType || fn.In(first+1) != requestType {
+			continue
+		}
+		if fn.NumOut() > 1 || (fn.NumOut() == 1 && fn.Out(0) != errorType) {
+			continue
+		}
+
+		for i := first + 2; i < fn.NumIn(); i++ {
+			params = append(params, fn.In(i))
+		}
+		return name, params, true
+	}
+
+	return "", nil, false
+}
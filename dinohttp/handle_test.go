@@ -0,0 +1,118 @@
+package dinohttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/frixuu/dino"
+	"github.com/stretchr/testify/assert"
+)
+
+type plainHandler struct {
+	Repo *repo
+}
+
+func (h *plainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.Repo != nil {
+		_, _ = w.Write([]byte("plain"))
+	}
+}
+
+type injectedHandler struct{}
+
+func (injectedHandler) Handle(w http.ResponseWriter, r *http.Request, u *user, repo *repo) error {
+	if u.Name == "" {
+		return errors.New("no user")
+	}
+	_, _ = w.Write([]byte("hello " + u.Name))
+	return nil
+}
+
+type invalidHandler struct{}
+
+func (invalidHandler) Handle(r *http.Request, w http.ResponseWriter) {}
+
+type routes interface {
+	ServeHTTP(w http.ResponseWriter, r *http.Request, u *user)
+}
+
+func serve(h http.Handler, user string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-User", user)
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandleCallsServeHTTP(t *testing.T) {
+	c := newRoot()
+	dino.MustAddTransient[*plainHandler, plainHandler](c)
+
+	rec := serve(Handle[*plainHandler](c), "")
+	assert.Equal(t, "plain", rec.Body.String())
+}
+
+func TestHandleInjectsMethodParameters(t *testing.T) {
+	c := newRoot()
+	dino.MustAddTransient[injectedHandler, injectedHandler](c)
+	handler := Middleware(c, Setup(withUser))(Handle[injectedHandler](c))
+
+	rec := serve(handler, "alice")
+	assert.Equal(t, "hello alice", rec.Body.String())
+
+	// Errors of the method are passed to the error handler
+	rec = serve(handler, "")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestHandleReportsMissingServices(t *testing.T) {
+	c := newRoot()
+	dino.MustAddTransient[injectedHandler, injectedHandler](c)
+
+	var handleErr error
+	handler := Handle[injectedHandler](c, OnError(func(w http.ResponseWriter, r *http.Request, err error) {
+		handleErr = err
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+
+	// Without the middleware, there is no user
+	rec := serve(handler, "alice")
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+	assert.ErrorIs(t, handleErr, dino.ErrBindingMissing)
+
+	rec = serve(HandleNamed[*plainHandler](c, "admin"), "alice")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+type userRoutes struct{}
+
+func (userRoutes) ServeHTTP(w http.ResponseWriter, r *http.Request, u *user) {
+	_, _ = w.Write([]byte("routes for " + u.Name))
+}
+
+func TestHandleAcceptsInterfaces(t *testing.T) {
+	method, params, ok := findHandlerMethod(reflectType[routes]())
+	assert.True(t, ok)
+	assert.Equal(t, "ServeHTTP", method)
+	assert.Equal(t, []reflect.Type{reflectType[*user]()}, params)
+
+	c := newRoot()
+	dino.MustAddTransient[routes, userRoutes](c)
+
+	rec := serve(Middleware(c, Setup(withUser))(Handle[routes](c)), "bob")
+	assert.Equal(t, "routes for bob", rec.Body.String())
+}
+
+func TestHandlePanicsWithoutHandlerMethod(t *testing.T) {
+	c := newRoot()
+	assert.PanicsWithValue(t, "dinohttp: dinohttp.invalidHandler has no ServeHTTP or Handle method accepting "+
+		"(http.ResponseWriter, *http.Request, ...)", func() { Handle[invalidHandler](c) })
+	assert.Panics(t, func() { Handle[*repo](c) })
+}
+
+func reflectType[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
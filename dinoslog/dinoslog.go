@@ -0,0 +1,77 @@
+//go:build go1.21
+
+// Package dinoslog logs services resolved by Dino containers with log/slog.
+package dinoslog
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/frixuu/dino"
+)
+
+// Observer logs events of resolving services with a structured logger.
+//
+// Resolutions and cache hits are logged at the debug level,
+// built services at the info level and failures at the error level.
+type Observer struct {
+	logger *slog.Logger
+}
+
+// New creates an observer logging with a provided logger.
+// If the logger is nil, slog.Default() is used.
+func New(logger *slog.Logger) *Observer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Observer{logger: logger}
+}
+
+func (o *Observer) ResolveStart(e dino.ResolveEvent) {
+	o.log(slog.LevelDebug, "dino: resolving", e)
+}
+
+func (o *Observer) ResolveEnd(e dino.ResolveEvent) {
+	o.log(slog.LevelDebug, "dino: resolved", e, slog.Duration("duration", e.Duration))
+}
+
+func (o *Observer) BindingBuilt(e dino.ResolveEvent) {
+	o.log(slog.LevelInfo, "dino: built", e, slog.Duration("duration", e.Duration))
+}
+
+func (o *Observer) CacheHit(e dino.ResolveEvent) {
+	o.log(slog.LevelDebug, "dino: cache hit", e)
+}
+
+func (o *Observer) Error(e dino.ResolveEvent) {
+	o.log(slog.LevelError, "dino: resolution failed", e, slog.Duration("duration", e.Duration), slog.Any("error", e.Err))
+}
+
+// log writes a record describing an event, if the logger is interested in the level.
+func (o *Observer) log(level slog.Level, msg string, e dino.ResolveEvent, attrs ...slog.Attr) {
+	ctx := context.Background()
+	if !o.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs = append([]slog.Attr{
+		slog.String("type", e.Type.String()),
+		slog.String("name", e.Name),
+		slog.String("lifetime", e.Lifetime.String()),
+		slog.String("chain", formatChain(e.Chain)),
+	}, attrs...)
+	o.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// formatChain describes a chain of resolutions, eg. "*app.Server -> app.Store (named:main)".
+func formatChain(chain []dino.DepLink) string {
+	parts := make([]string, len(chain))
+	for i, link := range chain {
+		parts[i] = link.Type().String()
+		if link.Name() != "" {
+			parts[i] += " (named:" + link.Name() + ")"
+		}
+	}
+	return strings.Join(parts, " -> ")
+}
@@ -0,0 +1,79 @@
+//go:build go1.21
+
+package dinoslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/frixuu/dino"
+	"github.com/stretchr/testify/assert"
+)
+
+type store struct{}
+
+type server struct {
+	Store *store `dino:"named:main"`
+}
+
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var out []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var rec map[string]any
+		assert.NoError(t, dec.Decode(&rec))
+		out = append(out, rec)
+	}
+	return out
+}
+
+func TestObserverLogs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c := &dino.Container{}
+	dino.MustAddNamed[*store, store](c, "main")
+	dino.MustAddTransient[*server, server](c)
+	c.SetObserver(New(logger))
+
+	dino.MustGet[*server](c)
+
+	recs := records(t, &buf)
+	msgs := make([]string, len(recs))
+	for i, rec := range recs {
+		msgs[i] = rec["msg"].(string)
+	}
+	assert.Equal(t, []string{
+		"dino: resolving", "dino: resolving", "dino: built", "dino: resolved", "dino: built", "dino: resolved",
+	}, msgs)
+
+	built := recs[2]
+	assert.Equal(t, "INFO", built["level"])
+	assert.Equal(t, "*dinoslog.store", built["type"])
+	assert.Equal(t, "main", built["name"])
+	assert.Equal(t, "singleton", built["lifetime"])
+	assert.Equal(t, "*dinoslog.server -> *dinoslog.store (named:main)", built["chain"])
+	assert.Contains(t, built, "duration")
+}
+
+func TestObserverLogsErrorsOnly(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	c := &dino.Container{}
+	dino.MustAddFactory(c, func(c *dino.Container) (*store, error) {
+		return nil, errors.New("no disk")
+	})
+	c.SetObserver(New(logger))
+
+	_, err := dino.Get[*store](c)
+	assert.Error(t, err)
+
+	recs := records(t, &buf)
+	assert.Len(t, recs, 1)
+	assert.Equal(t, "dino: resolution failed", recs[0]["msg"])
+	assert.Equal(t, "while resolving *dinoslog.store: no disk", recs[0]["error"])
+}
@@ -0,0 +1,200 @@
+// Package dinotel reports services resolved by Dino containers as trace spans and metrics.
+//
+// It does not depend on any telemetry library. Instead, spans and metrics are emitted through
+// small interfaces, modelled after OpenTelemetry, which are easy to adapt to an actual SDK.
+// MemoryExporter implements all of them for use in tests.
+package dinotel
+
+import (
+	"sync"
+	"time"
+
+	"github.com/frixuu/dino"
+)
+
+// Names of the metrics reported by the Observer.
+const (
+	MetricResolutions      = "dino.resolutions"         // Counter of finished resolutions.
+	MetricResolutionErrors = "dino.resolution.errors"   // Counter of failed resolutions.
+	MetricCacheHits        = "dino.cache.hits"          // Counter of singletons provided from their cache.
+	MetricResolveDuration  = "dino.resolution.duration" // Histogram of resolution durations, in seconds.
+	MetricBuildDuration    = "dino.build.duration"      // Histogram of building durations, in seconds.
+)
+
+// Attribute is a key-value pair describing a span or a measurement.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Tracer starts spans.
+type Tracer interface {
+	// StartSpan starts a span at a provided time.
+	// Parent is nil for spans that do not have a parent.
+	StartSpan(name string, parent Span, start time.Time, attrs ...Attribute) Span
+}
+
+// Span describes a single operation within a trace.
+type Span interface {
+	// RecordError marks the operation as failed.
+	RecordError(err error)
+	// End finishes the span at a provided time.
+	End(end time.Time)
+}
+
+// Meter creates instruments for reporting measurements.
+type Meter interface {
+	Counter(name string) Counter
+	Histogram(name string) Histogram
+}
+
+// Counter reports values that only go up.
+type Counter interface {
+	Add(n int64, attrs ...Attribute)
+}
+
+// Histogram reports distributions of values.
+type Histogram interface {
+	Record(v float64, attrs ...Attribute)
+}
+
+// pendingSpan describes a resolution in progress, which might become a span.
+type pendingSpan struct {
+	event dino.ResolveEvent
+	start time.Time
+	span  Span // Set once the span gets started.
+}
+
+// Observer emits a span for each service built by a container, as well as metrics of resolutions.
+//
+// Spans are nested the same way as the dependencies being built.
+// Resolutions that do not build anything (eg. cache hits of singletons) do not get their own spans,
+// unless a dependency gets built while they are in progress.
+type Observer struct {
+	tracer Tracer
+	mu     sync.Mutex
+	// Resolutions in progress, by their IDs.
+	pending map[uint64]*pendingSpan
+
+	resolutions     Counter
+	errors          Counter
+	cacheHits       Counter
+	resolveDuration Histogram
+	buildDuration   Histogram
+}
+
+// New creates an observer reporting to a provided tracer and meter.
+// Either of them can be nil, if spans or metrics are not needed.
+func New(tracer Tracer, meter Meter) *Observer {
+	o := &Observer{
+		tracer:  tracer,
+		pending: make(map[uint64]*pendingSpan),
+	}
+
+	if meter != nil {
+		o.resolutions = meter.Counter(MetricResolutions)
+		o.errors = meter.Counter(MetricResolutionErrors)
+		o.cacheHits = meter.Counter(MetricCacheHits)
+		o.resolveDuration = meter.Histogram(MetricResolveDuration)
+		o.buildDuration = meter.Histogram(MetricBuildDuration)
+	}
+
+	return o
+}
+
+func (o *Observer) ResolveStart(e dino.ResolveEvent) {
+	if o.tracer == nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.pending[e.ID] = &pendingSpan{event: e, start: time.Now()}
+}
+
+func (o *Observer) ResolveEnd(e dino.ResolveEvent) {
+	o.finish(e, nil)
+	if o.resolutions != nil {
+		o.resolutions.Add(1, Attribute{Key: "dino.lifetime", Value: e.Lifetime.String()})
+		o.resolveDuration.Record(e.Duration.Seconds(), Attribute{Key: "dino.lifetime", Value: e.Lifetime.String()})
+	}
+}
+
+func (o *Observer) BindingBuilt(e dino.ResolveEvent) {
+	if o.tracer != nil {
+		o.mu.Lock()
+		o.startSpan(e.ID)
+		o.mu.Unlock()
+	}
+
+	if o.buildDuration != nil {
+		o.buildDuration.Record(e.Duration.Seconds(), attributes(e)...)
+	}
+}
+
+func (o *Observer) CacheHit(e dino.ResolveEvent) {
+	if o.cacheHits != nil {
+		o.cacheHits.Add(1, Attribute{Key: "dino.type", Value: e.Type.String()})
+	}
+}
+
+func (o *Observer) Error(e dino.ResolveEvent) {
+	o.finish(e, e.Err)
+	if o.resolutions != nil {
+		o.resolutions.Add(1, Attribute{Key: "dino.lifetime", Value: e.Lifetime.String()})
+		o.errors.Add(1, attributes(e)...)
+		o.resolveDuration.Record(e.Duration.Seconds(), Attribute{Key: "dino.lifetime", Value: e.Lifetime.String()})
+	}
+}
+
+// finish ends the span of a resolution, if it has been started.
+// Failed resolutions always get a span, so that errors show up in traces.
+func (o *Observer) finish(e dino.ResolveEvent, err error) {
+	if o.tracer == nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	// The observer might have been set while the resolution was in progress
+	p, ok := o.pending[e.ID]
+	if !ok {
+		return
+	}
+
+	span := p.span
+	if err != nil {
+		span = o.startSpan(e.ID)
+		span.RecordError(err)
+	}
+	if span != nil {
+		span.End(p.start.Add(e.Duration))
+	}
+	delete(o.pending, e.ID)
+}
+
+// startSpan starts the span of a resolution in progress, along with the spans of its parents,
+// and returns it. It must be called with the lock held.
+func (o *Observer) startSpan(id uint64) Span {
+	p, ok := o.pending[id]
+	if !ok {
+		return nil
+	}
+	if p.span != nil {
+		return p.span
+	}
+
+	parent := o.startSpan(p.event.ParentID)
+	p.span = o.tracer.StartSpan("dino: build "+p.event.Type.String(), parent, p.start, attributes(p.event)...)
+	return p.span
+}
+
+// attributes describes the service being resolved.
+func attributes(e dino.ResolveEvent) []Attribute {
+	return []Attribute{
+		{Key: "dino.type", Value: e.Type.String()},
+		{Key: "dino.name", Value: e.Name},
+		{Key: "dino.lifetime", Value: e.Lifetime.String()},
+	}
+}
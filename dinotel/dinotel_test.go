@@ -0,0 +1,99 @@
+package dinotel
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/frixuu/dino"
+	"github.com/stretchr/testify/assert"
+)
+
+type store struct{}
+
+type cache struct {
+	Store *store
+}
+
+type server struct {
+	Cache *cache
+	Store *store `dino:"named:main"`
+}
+
+func TestObserverNestsSpans(t *testing.T) {
+	exp := NewMemoryExporter()
+	c := &dino.Container{}
+	dino.MustAdd[*store, store](c)
+	dino.MustAddNamed[*store, store](c, "main")
+	dino.MustAdd[*cache, cache](c)
+	dino.MustAddTransient[*server, server](c)
+	c.SetObserver(New(exp, exp))
+
+	dino.MustGet[*store](c)
+	dino.MustGet[*server](c)
+
+	spans := exp.Spans()
+	assert.Len(t, spans, 4)
+
+	assert.Equal(t, "dino: build *dinotel.store", spans[0].Name)
+	assert.Equal(t, 0, spans[0].ParentID)
+
+	// The store has already been built, so it does not get its own span the second time
+	assert.Equal(t, "dino: build *dinotel.server", spans[1].Name)
+	assert.Equal(t, 0, spans[1].ParentID)
+	assert.Equal(t, "transient", spans[1].Attributes["dino.lifetime"])
+	assert.Equal(t, "dino: build *dinotel.cache", spans[2].Name)
+	assert.Equal(t, spans[1].ID, spans[2].ParentID)
+	assert.Equal(t, "dino: build *dinotel.store", spans[3].Name)
+	assert.Equal(t, spans[1].ID, spans[3].ParentID)
+	assert.Equal(t, "main", spans[3].Attributes["dino.name"])
+
+	for _, span := range spans {
+		assert.True(t, span.Ended)
+		assert.False(t, span.End.Before(span.Start))
+		assert.NoError(t, span.Err)
+	}
+}
+
+func TestObserverRecordsMetrics(t *testing.T) {
+	exp := NewMemoryExporter()
+	c := &dino.Container{}
+	dino.MustAdd[*store, store](c)
+	c.SetObserver(New(nil, exp))
+
+	dino.MustGet[*store](c)
+	dino.MustGet[*store](c)
+
+	assert.Equal(t, int64(2), exp.CounterValue(MetricResolutions))
+	assert.Equal(t, int64(1), exp.CounterValue(MetricCacheHits))
+	assert.Equal(t, int64(0), exp.CounterValue(MetricResolutionErrors))
+	assert.Len(t, exp.HistogramValues(MetricResolveDuration), 2)
+	assert.Len(t, exp.HistogramValues(MetricBuildDuration), 1)
+	assert.Empty(t, exp.Spans())
+}
+
+func TestObserverReportsErrors(t *testing.T) {
+	exp := NewMemoryExporter()
+	c := &dino.Container{}
+	dino.MustAddFactory(c, func(c *dino.Container) (*store, error) {
+		return nil, errors.New("no disk")
+	})
+	dino.MustAdd[*cache, cache](c)
+	c.SetObserver(New(exp, exp))
+
+	_, err := dino.Get[*cache](c)
+	assert.Error(t, err)
+
+	spans := exp.Spans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "dino: build *dinotel.cache", spans[0].Name)
+	assert.Equal(t, "dino: build *dinotel.store", spans[1].Name)
+	assert.Equal(t, spans[0].ID, spans[1].ParentID)
+	assert.EqualError(t, spans[1].Err, "while resolving *dinotel.cache → *dinotel.store: no disk")
+	for _, span := range spans {
+		assert.True(t, span.Ended)
+		assert.Error(t, span.Err)
+	}
+
+	assert.Equal(t, int64(2), exp.CounterValue(MetricResolutionErrors))
+	assert.Empty(t, exp.HistogramValues(MetricBuildDuration))
+}
@@ -0,0 +1,137 @@
+package dinotel
+
+import (
+	"sync"
+	"time"
+)
+
+// MemorySpan is a span recorded by a MemoryExporter.
+type MemorySpan struct {
+	ID         int // Position of the span in the order of starting, counting from 1.
+	ParentID   int // ID of the parent span, or 0 if there is none.
+	Name       string
+	Attributes map[string]any
+	Start      time.Time
+	End        time.Time
+	Ended      bool
+	Err        error
+}
+
+// MemoryExporter records spans and metrics in memory, so that tests can inspect them.
+// It implements Tracer and Meter.
+type MemoryExporter struct {
+	mu         sync.Mutex
+	spans      []*MemorySpan
+	counters   map[string]int64
+	histograms map[string][]float64
+}
+
+// NewMemoryExporter creates an empty exporter.
+func NewMemoryExporter() *MemoryExporter {
+	return &MemoryExporter{
+		counters:   make(map[string]int64),
+		histograms: make(map[string][]float64),
+	}
+}
+
+// Spans returns copies of all the spans started so far.
+func (m *MemoryExporter) Spans() []MemorySpan {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	spans := make([]MemorySpan, len(m.spans))
+	for i, s := range m.spans {
+		spans[i] = *s
+	}
+	return spans
+}
+
+// CounterValue returns the sum of values added to a counter so far.
+func (m *MemoryExporter) CounterValue(name string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.counters[name]
+}
+
+// HistogramValues returns all the values recorded by a histogram so far.
+func (m *MemoryExporter) HistogramValues(name string) []float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]float64(nil), m.histograms[name]...)
+}
+
+func (m *MemoryExporter) StartSpan(name string, parent Span, start time.Time, attrs ...Attribute) Span {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := &MemorySpan{
+		ID:         len(m.spans) + 1,
+		Name:       name,
+		Attributes: make(map[string]any, len(attrs)),
+		Start:      start,
+	}
+	if p, ok := parent.(*memorySpan); ok {
+		s.ParentID = p.span.ID
+	}
+	for _, attr := range attrs {
+		s.Attributes[attr.Key] = attr.Value
+	}
+
+	m.spans = append(m.spans, s)
+	return &memorySpan{exporter: m, span: s}
+}
+
+func (m *MemoryExporter) Counter(name string) Counter {
+	return memoryCounter{exporter: m, name: name}
+}
+
+func (m *MemoryExporter) Histogram(name string) Histogram {
+	return memoryHistogram{exporter: m, name: name}
+}
+
+// memorySpan is a handle of a span recorded by a MemoryExporter.
+type memorySpan struct {
+	exporter *MemoryExporter
+	span     *MemorySpan
+}
+
+func (s *memorySpan) RecordError(err error) {
+	s.exporter.mu.Lock()
+	defer s.exporter.mu.Unlock()
+
+	s.span.Err = err
+}
+
+func (s *memorySpan) End(end time.Time) {
+	s.exporter.mu.Lock()
+	defer s.exporter.mu.Unlock()
+
+	s.span.End = end
+	s.span.Ended = true
+}
+
+type memoryCounter struct {
+	exporter *MemoryExporter
+	name     string
+}
+
+func (c memoryCounter) Add(n int64, attrs ...Attribute) {
+	c.exporter.mu.Lock()
+	defer c.exporter.mu.Unlock()
+
+	c.exporter.counters[c.name] += n
+}
+
+type memoryHistogram struct {
+	exporter *MemoryExporter
+	name     string
+}
+
+func (h memoryHistogram) Record(v float64, attrs ...Attribute) {
+	h.exporter.mu.Lock()
+	defer h.exporter.mu.Unlock()
+
+	h.exporter.histograms[h.name] = append(h.exporter.histograms[h.name], v)
+}
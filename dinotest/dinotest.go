@@ -0,0 +1,36 @@
+// Package dinotest provides helpers for using Dino containers in tests.
+package dinotest
+
+import (
+	"testing"
+
+	"github.com/frixuu/dino"
+)
+
+// Override replaces the service of type T in a global namespace with a fake
+// for the duration of a test.
+//
+// The previous registration gets restored once the test and all its subtests complete.
+// Sealed containers can be overridden too, eg. clones of a sealed production container.
+// Singletons built with the previous service are rebuilt, so that they pick up the fake.
+func Override[T any](t testing.TB, c *dino.Container, fake T) {
+	t.Helper()
+	OverrideNamed(t, c, "", fake)
+}
+
+// OverrideNamed replaces the service of type T under a provided namespace with a fake
+// for the duration of a test.
+//
+// The previous registration gets restored once the test and all its subtests complete.
+// Sealed containers can be overridden too, eg. clones of a sealed production container.
+// Singletons built with the previous service are rebuilt, so that they pick up the fake.
+func OverrideNamed[T any](t testing.TB, c *dino.Container, name string, fake T) {
+	t.Helper()
+
+	restore, err := dino.ReplaceNamed(c, name, fake)
+	if err != nil {
+		t.Fatalf("dinotest: cannot override service: %v", err)
+	}
+
+	t.Cleanup(restore)
+}
@@ -0,0 +1,70 @@
+package dinotest
+
+import (
+	"testing"
+
+	"github.com/frixuu/dino"
+	"github.com/stretchr/testify/assert"
+)
+
+type mailer interface {
+	Send(to string) string
+}
+
+type smtpMailer struct{}
+
+func (*smtpMailer) Send(to string) string { return "smtp:" + to }
+
+type fakeMailer struct{}
+
+func (*fakeMailer) Send(to string) string { return "fake:" + to }
+
+type signup struct {
+	Mailer mailer
+	Admin  mailer `dino:"named:admin"`
+}
+
+func newContainer() *dino.Container {
+	c := &dino.Container{}
+	dino.MustAdd[mailer, smtpMailer](c)
+	dino.MustAliasNamed[mailer, mailer](c, "admin", "")
+	dino.MustAdd[*signup, signup](c)
+	return c
+}
+
+func TestOverride(t *testing.T) {
+	c := newContainer()
+	assert.Equal(t, "smtp:bob", dino.MustGet[*signup](c).Mailer.Send("bob"))
+
+	t.Run("overridden", func(t *testing.T) {
+		Override[mailer](t, c, &fakeMailer{})
+		s := dino.MustGet[*signup](c)
+		assert.Equal(t, "fake:bob", s.Mailer.Send("bob"))
+		assert.Equal(t, "fake:bob", s.Admin.Send("bob"))
+	})
+
+	assert.Equal(t, "smtp:bob", dino.MustGet[*signup](c).Mailer.Send("bob"))
+}
+
+func TestOverrideInCloneOfSealedContainer(t *testing.T) {
+	prod := newContainer()
+	assert.Nil(t, prod.Seal())
+
+	c := prod.Clone()
+	Override[mailer](t, c, &fakeMailer{})
+	assert.Equal(t, "fake:bob", dino.MustGet[*signup](c).Mailer.Send("bob"))
+	assert.Equal(t, "smtp:bob", dino.MustGet[*signup](prod).Mailer.Send("bob"))
+}
+
+func TestOverrideNamed(t *testing.T) {
+	c := newContainer()
+
+	t.Run("overridden", func(t *testing.T) {
+		OverrideNamed[mailer](t, c, "admin", &fakeMailer{})
+		s := dino.MustGet[*signup](c)
+		assert.Equal(t, "smtp:bob", s.Mailer.Send("bob"))
+		assert.Equal(t, "fake:bob", s.Admin.Send("bob"))
+	})
+
+	assert.Equal(t, "smtp:bob", dino.MustGet[*signup](c).Admin.Send("bob"))
+}
@@ -0,0 +1,217 @@
+package dinotest
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/frixuu/dino"
+)
+
+// Call describes a single call made to a stub.
+type Call struct {
+	Method string // Name of the called method, or an empty string for stubbed functions.
+	Args   []any
+}
+
+// Recorder records calls made to a stub and provides results configured for them.
+type Recorder struct {
+	ty      reflect.Type
+	name    string
+	mu      sync.Mutex
+	calls   []Call
+	results map[string][]any
+	stub    reflect.Value // The stub reporting to the recorder.
+}
+
+// Type returns the type of the stubbed service.
+func (r *Recorder) Type() reflect.Type {
+	return r.ty
+}
+
+// Name returns the namespace of the stubbed service.
+func (r *Recorder) Name() string {
+	return r.name
+}
+
+// Call records a call of a method and returns results configured for it with Returns.
+//
+// It is meant to be called by stubs created by mock factories.
+func (r *Recorder) Call(method string, args ...any) []any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+	return r.results[method]
+}
+
+// Returns configures results of all the following calls of a method.
+// For stubbed functions, the method name should be empty.
+func (r *Recorder) Returns(method string, results ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results[method] = results
+}
+
+// Calls returns all the calls made to the stub so far.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns calls of a method made to the stub so far.
+func (r *Recorder) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range r.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Result returns the i-th of the results returned by Recorder.Call as type T,
+// or the zero value of T if there is no such result.
+func Result[T any](results []any, i int) (result T) {
+	if i < len(results) {
+		result, _ = results[i].(T)
+	}
+	return
+}
+
+// MockFactory creates stubs of services.
+//
+// It gets the type of the service and a recorder the stub should report its calls to.
+// If it cannot create a stub of the type, it should return false.
+type MockFactory func(ty reflect.Type, r *Recorder) (stub any, ok bool)
+
+// stubKey identifies a stub by the type and name of the service.
+type stubKey struct {
+	ty   reflect.Type
+	name string
+}
+
+// Mocks creates recording stubs for dependencies that do not have any bindings in a container.
+type Mocks struct {
+	mu        sync.Mutex
+	factories []MockFactory
+	stubs     map[stubKey]*Recorder
+}
+
+// Automock makes a container fill dependencies that do not have any bindings with recording stubs,
+// until the test and all its subtests complete. Then, the previous handler of missing services is restored.
+//
+// Functions are stubbed automatically and return results configured with Recorder.Returns,
+// or zero values. Interfaces are stubbed by mock factories, added with Factory or Mocks.Use.
+// Dependencies that cannot be stubbed are left as they are.
+//
+// The same stub gets injected into all the services depending on it.
+func Automock(t testing.TB, c *dino.Container) *Mocks {
+	t.Helper()
+
+	m := &Mocks{stubs: make(map[stubKey]*Recorder)}
+	t.Cleanup(c.ReplaceMissingHandler(m.provide))
+
+	return m
+}
+
+// Factory adds a mock factory creating stubs of type T.
+func Factory[T any](m *Mocks, factory func(r *Recorder) T) {
+	ty := reflect.TypeOf((*T)(nil)).Elem()
+	m.Use(func(t reflect.Type, r *Recorder) (any, bool) {
+		if t != ty {
+			return nil, false
+		}
+		return factory(r), true
+	})
+}
+
+// Use adds a mock factory.
+//
+// Factories added later take precedence over the earlier ones.
+func (m *Mocks) Use(factory MockFactory) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.factories = append(m.factories, factory)
+}
+
+// Stub returns the recorder of a stub of type T in a global namespace,
+// or nil if no such stub has been injected.
+func Stub[T any](m *Mocks) *Recorder {
+	return StubNamed[T](m, "")
+}
+
+// StubNamed returns the recorder of a stub of type T under a provided namespace,
+// or nil if no such stub has been injected.
+func StubNamed[T any](m *Mocks, name string) *Recorder {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.stubs[stubKey{ty: reflect.TypeOf((*T)(nil)).Elem(), name: name}]
+}
+
+// provide is the handler of missing services of the container.
+func (m *Mocks) provide(ty reflect.Type, name string) (reflect.Value, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := stubKey{ty: ty, name: name}
+	if r, ok := m.stubs[key]; ok {
+		return r.stub, true
+	}
+
+	r := &Recorder{ty: ty, name: name, results: make(map[string][]any)}
+	stub, ok := m.create(ty, r)
+	if !ok {
+		return reflect.Value{}, false
+	}
+
+	r.stub = stub
+	m.stubs[key] = r
+	return stub, true
+}
+
+// create creates a stub of a type, reporting to a provided recorder.
+func (m *Mocks) create(ty reflect.Type, r *Recorder) (reflect.Value, bool) {
+	for i := len(m.factories) - 1; i >= 0; i-- {
+		if stub, ok := m.factories[i](ty, r); ok && stub != nil {
+			return reflect.ValueOf(stub), true
+		}
+	}
+
+	if ty.Kind() == reflect.Func {
+		return reflect.MakeFunc(ty, func(args []reflect.Value) []reflect.Value {
+			values := make([]any, len(args))
+			for i, arg := range args {
+				values[i] = arg.Interface()
+			}
+			return funcResults(ty, r.Call("", values...))
+		}), true
+	}
+
+	return reflect.Value{}, false
+}
+
+// funcResults converts configured results to values a stubbed function of a provided type returns.
+func funcResults(ty reflect.Type, results []any) []reflect.Value {
+	values := make([]reflect.Value, ty.NumOut())
+	for i := range values {
+		out := ty.Out(i)
+		if i >= len(results) || results[i] == nil {
+			values[i] = reflect.Zero(out)
+			continue
+		}
+
+		v := reflect.ValueOf(results[i])
+		if !v.Type().AssignableTo(out) {
+			panic(fmt.Sprintf("dinotest: result %d of a stub of %s should be %s, got %s", i, ty, out, v.Type()))
+		}
+		values[i] = v
+	}
+	return values
+}
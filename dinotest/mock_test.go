@@ -0,0 +1,125 @@
+package dinotest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/frixuu/dino"
+	"github.com/stretchr/testify/assert"
+)
+
+type userStore interface {
+	Find(id int) (string, error)
+}
+
+type userStoreStub struct {
+	r *Recorder
+}
+
+func (s *userStoreStub) Find(id int) (string, error) {
+	results := s.r.Call("Find", id)
+	return Result[string](results, 0), Result[error](results, 1)
+}
+
+type greeter struct {
+	Users  userStore
+	Mailer mailer
+	Audit  func(event string, id int) bool `dino:"named:audit"`
+	Limits *limits
+}
+
+type limits struct {
+	Max int
+}
+
+func (g *greeter) Greet(id int) (string, error) {
+	name, err := g.Users.Find(id)
+	if err != nil {
+		return "", err
+	}
+	g.Audit("greet", id)
+	return "hello " + name, nil
+}
+
+func TestAutomockStubsFunctions(t *testing.T) {
+	c := &dino.Container{}
+	dino.MustAddTransient[*greeter, greeter](c)
+	m := Automock(t, c)
+
+	g := dino.MustGet[*greeter](c)
+	assert.NotNil(t, g.Audit)
+	assert.Nil(t, g.Users)
+	assert.Nil(t, g.Mailer)
+	assert.Nil(t, g.Limits)
+
+	audit := StubNamed[func(string, int) bool](m, "audit")
+	assert.False(t, g.Audit("first", 1))
+	audit.Returns("", true)
+	assert.True(t, g.Audit("second", 2))
+	assert.Equal(t, []Call{{Args: []any{"first", 1}}, {Args: []any{"second", 2}}}, audit.Calls())
+
+	// Other services get the same stub
+	assert.Same(t, audit, StubNamed[func(string, int) bool](m, "audit"))
+	dino.MustGet[*greeter](c).Audit("third", 3)
+	assert.Len(t, audit.Calls(), 3)
+}
+
+func TestAutomockUsesFactories(t *testing.T) {
+	c := &dino.Container{}
+	dino.MustAddTransient[*greeter, greeter](c)
+	dino.MustAdd[mailer, smtpMailer](c)
+	m := Automock(t, c)
+	Factory(m, func(r *Recorder) userStore { return &userStoreStub{r: r} })
+	m.Use(func(ty reflect.Type, r *Recorder) (any, bool) {
+		return nil, ty == reflect.TypeOf((*limits)(nil))
+	})
+
+	g := dino.MustGet[*greeter](c)
+	assert.Equal(t, "smtp:bob", g.Mailer.Send("bob"))
+	assert.Nil(t, g.Limits)
+	assert.Nil(t, Stub[mailer](m))
+
+	users := Stub[userStore](m)
+	users.Returns("Find", "bob")
+	msg, err := g.Greet(7)
+	assert.Nil(t, err)
+	assert.Equal(t, "hello bob", msg)
+	assert.Equal(t, []Call{{Method: "Find", Args: []any{7}}}, users.CallsTo("Find"))
+	assert.Len(t, StubNamed[func(string, int) bool](m, "audit").Calls(), 1)
+
+	users.Returns("Find", nil, errors.New("not found"))
+	_, err = g.Greet(8)
+	assert.EqualError(t, err, "not found")
+}
+
+func TestAutomockCleanup(t *testing.T) {
+	c := &dino.Container{}
+	dino.MustAddTransient[*greeter, greeter](c)
+
+	t.Run("mocked", func(t *testing.T) {
+		Automock(t, c)
+		assert.NotNil(t, dino.MustGet[*greeter](c).Audit)
+	})
+
+	assert.Nil(t, dino.MustGet[*greeter](c).Audit)
+}
+
+func TestAutomockRestoresPreviousHandler(t *testing.T) {
+	audit := func(event string, id int) bool { return true }
+	parent := &dino.Container{}
+	parent.SetMissingHandler(func(ty reflect.Type, name string) (reflect.Value, bool) {
+		return reflect.ValueOf(audit), ty == reflect.TypeOf(audit)
+	})
+
+	c := parent.NewChild()
+	dino.MustAddTransient[*greeter, greeter](c)
+
+	t.Run("mocked", func(t *testing.T) {
+		Automock(t, c)
+		assert.False(t, dino.MustGet[*greeter](c).Audit("greet", 1))
+	})
+
+	// The child uses the handler of its parent again
+	assert.True(t, dino.MustGet[*greeter](c).Audit("greet", 2))
+}
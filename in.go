@@ -0,0 +1,121 @@
+package dino
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// In marks parameter structs, which bundle dependencies of something
+// and can be resolved without being registered:
+//
+//	type Deps struct {
+//		dino.In
+//		DB    *sql.DB
+//		Cache Cache `dino:"named:hot;optional"`
+//	}
+//
+// Requesting such a struct (or a pointer to one) in a global namespace, either directly or as a field,
+// creates a new one each time, with its fields resolved from the container, just like when injecting them
+// (exported interfaces, pointers and structs, and other fields only if they have a dino tag).
+// Unlike fields of other structs, fields that do not have bindings are errors,
+// unless they are tagged as optional or are untagged structs. If several fields fail, the error lists all of them.
+//
+// A parameter struct can still be registered like any other service, which then takes precedence.
+type In struct{}
+
+var inType = reflect.TypeOf(In{})
+
+// ErrNotIn is returned by Resolve, when the struct provided for resolution does not embed In.
+var ErrNotIn = errors.New("struct provided for resolution does not embed dino.In")
+
+// inBindings caches bindings of parameter structs by the requested type.
+var inBindings sync.Map
+
+// inBinding returns a binding creating a parameter struct from scratch each time it is requested,
+// or nil if the requested type is not a parameter struct.
+func inBinding(ty reflect.Type, name string) Binding {
+	if name != "" {
+		return nil
+	}
+
+	implType, byValue := ty, true
+	if ty.Kind() == reflect.Pointer {
+		implType, byValue = ty.Elem(), false
+	}
+	if implType.Kind() != reflect.Struct || !getPlan(implType).in {
+		return nil
+	}
+
+	b, _ := inBindings.LoadOrStore(ty, &transientBinding{implType: implType, byValue: byValue})
+	return b.(Binding)
+}
+
+// Resolve fills fields of a parameter struct with services from the container.
+// The target must be a pointer to a struct embedding In. Fields that are already set are left as they are.
+func Resolve(c *Container, target any) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return ErrPtrNotToStruct
+	}
+	if !getPlan(value.Elem().Type()).in {
+		return ErrNotIn
+	}
+
+	return injectFields(value, c, c.newChain())
+}
+
+// FieldsError occurs when one or more fields of a parameter struct cannot be resolved.
+type FieldsError struct {
+	ty   reflect.Type
+	errs []FieldError
+}
+
+// Type returns the type of the struct, usually a pointer.
+func (e FieldsError) Type() reflect.Type {
+	return e.ty
+}
+
+// Errors returns errors of all the fields that failed, in the order of the fields.
+func (e FieldsError) Errors() []FieldError {
+	return e.errs
+}
+
+func (e FieldsError) Unwrap() []error {
+	errs := make([]error, len(e.errs))
+	for i, err := range e.errs {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Is makes errors.Is look at errors of all the fields.
+func (e FieldsError) Is(target error) bool {
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e FieldsError) Error() string {
+	var b strings.Builder
+	b.WriteString("cannot resolve ")
+	b.WriteString(strconv.Itoa(len(e.errs)))
+	if len(e.errs) == 1 {
+		b.WriteString(" field of ")
+	} else {
+		b.WriteString(" fields of ")
+	}
+	b.WriteString(e.ty.String())
+	b.WriteString(":")
+	for _, err := range e.errs {
+		// Errors of nested parameter structs get indented further
+		b.WriteString("\n\t")
+		b.WriteString(strings.ReplaceAll(err.Error(), "\n", "\n\t"))
+	}
+	return b.String()
+}
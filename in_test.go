@@ -0,0 +1,110 @@
+package dino
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type schedulerDeps struct {
+	In
+	Clock  clock
+	Fake   clock      `dino:"named:fake"`
+	Sender mailSender `dino:"optional"`
+	Store  *myStruct1 `dino:"named:store;optional"`
+	unused *realClock
+}
+
+type reportDeps struct {
+	In
+	Deps schedulerDeps
+	Mail mailSender
+}
+
+type selfDeps struct {
+	In
+	Self *selfDeps
+}
+
+func TestGetResolvesParameterStructs(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[clock, realClock](c))
+	assert.Nil(t, AddNamed[clock, fakeClock](c, "fake"))
+
+	deps, err := Get[schedulerDeps](c)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, deps.Clock.Now())
+	assert.Equal(t, 2, deps.Fake.Now())
+	assert.Nil(t, deps.Sender)
+	assert.Nil(t, deps.Store)
+
+	// Nothing gets cached
+	first := MustGet[*schedulerDeps](c)
+	second := MustGet[*schedulerDeps](c)
+	assert.NotSame(t, first, second)
+	assert.Len(t, c.Bindings(), 2)
+
+	// Parameter structs are only resolved ad hoc in the global namespace
+	_, err = GetNamed[schedulerDeps](c, "other")
+	assert.ErrorIs(t, err, ErrBindingMissing)
+}
+
+func TestGetReportsAllFailedFields(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[clock, realClock](c))
+
+	_, err := Get[reportDeps](c)
+	assert.ErrorIs(t, err, ErrBindingMissing)
+	assert.EqualError(t, err, "cannot resolve 2 fields of *dino.reportDeps:\n"+
+		"\t*dino.reportDeps.Deps → dino.schedulerDeps: cannot resolve 1 field of *dino.schedulerDeps:\n"+
+		"\t\t*dino.schedulerDeps.Fake (named:fake) → dino.clock: binding missing; did you mean dino.clock?\n"+
+		"\t*dino.reportDeps.Mail → dino.mailSender: binding missing")
+
+	var fieldsErr FieldsError
+	assert.True(t, errors.As(err, &fieldsErr))
+	assert.Len(t, fieldsErr.Errors(), 2)
+	assert.Equal(t, "Mail", fieldsErr.Errors()[1].Field())
+	assert.Equal(t, getType[*reportDeps](), fieldsErr.Type())
+}
+
+func TestResolveFillsParameterStructs(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[clock, realClock](c))
+	assert.Nil(t, AddNamed[clock, fakeClock](c, "fake"))
+	assert.Nil(t, Add[mailSender, fakeMailSender](c))
+
+	var deps reportDeps
+	assert.Nil(t, Resolve(c, &deps))
+	assert.Equal(t, 2, deps.Deps.Fake.Now())
+	assert.Equal(t, "fake:bob", deps.Mail.Send("bob"))
+	assert.Same(t, deps.Mail, deps.Deps.Sender)
+
+	assert.ErrorIs(t, Resolve(c, &scheduler{}), ErrNotIn)
+	assert.ErrorIs(t, Resolve(c, deps), ErrPtrNotToStruct)
+	assert.Panics(t, func() { MustResolve(c, &scheduler{}) })
+}
+
+func TestParameterStructsInChildAndSealedContainers(t *testing.T) {
+	root := &Container{}
+	assert.Nil(t, Add[clock, realClock](root))
+	assert.Nil(t, Add[*scheduler, scheduler](root))
+
+	child := root.NewChild()
+	assert.Nil(t, AddNamed[clock, fakeClock](child, "fake"))
+	deps := MustGet[schedulerDeps](child)
+	assert.Equal(t, 2, deps.Fake.Now())
+
+	assert.Nil(t, AddNamed[clock, fakeClock](root, "fake"))
+	assert.Nil(t, Add[*reportDeps, reportDeps](root))
+	assert.Nil(t, Add[mailSender, realMailSender](root))
+	assert.Nil(t, root.Seal())
+	report := MustGet[*reportDeps](root)
+	assert.Equal(t, 2, report.Deps.Fake.Now())
+}
+
+func TestParameterStructsCannotDependOnThemselves(t *testing.T) {
+	c := &Container{}
+	_, err := Get[*selfDeps](c)
+	assert.ErrorIs(t, err, ErrCyclicDependency)
+}
@@ -3,6 +3,7 @@ package dino
 import (
 	"errors"
 	"reflect"
+	"strings"
 )
 
 var ErrNotIfOrPtr = errors.New("reflected value was not an interface nor a pointer")
@@ -20,46 +21,179 @@ func injectFields(value reflect.Value, c *Container, chain []DepLink) error {
 		return ErrPtrNotToStruct
 	}
 
-	ty := element.Type()
-	fieldCount := element.NumField()
-	for i := 0; i < fieldCount; i++ {
+	plan := getPlan(element.Type())
+	if len(plan.fields) == 0 {
+		return nil
+	}
 
-		// We can only set exported fields
-		fieldValue := element.Field(i)
-		if !fieldValue.CanSet() {
-			continue
-		}
+	// Sealed containers know bindings of all the fields in advance,
+	// but children still need to look for the missing ones in their parents
+	var bindings []Binding
+	if s := c.sealedBindings(); s != nil {
+		bindings = s.plans[element.Type()]
+	}
 
-		field := ty.Field(i)
-		fieldType := field.Type
+	// Structs embedding In report all the fields that failed at once
+	var errs []FieldError
+	for i, field := range plan.fields {
 
-		// We perform injection only if a field is an interface or a pointer to a struct
-		isIf := fieldType.Kind() == reflect.Interface
-		isPtr := fieldType.Kind() == reflect.Pointer && fieldType.Elem().Kind() == reflect.Struct
-		if !isIf && !isPtr {
+		// Do not overwrite values that were already set
+		fieldValue := element.Field(field.index)
+		if !fieldValue.IsZero() {
 			continue
 		}
 
-		if !fieldValue.IsNil() {
-			continue
+		var svc reflect.Value
+		var err error
+		if bindings != nil && bindings[i] != nil {
+			svc, err = c.provide(field.key.ty, field.key.name, bindings[i], chain)
+		} else if bindings != nil && c.parent == nil {
+			// Most missing fields are simply left empty, so suggestions only get looked for when reporting them
+			err = BindingMissingError{ty: field.key.ty, name: field.key.name}
+		} else if _, _, ok := c.lookup(field.key.ty, field.key.name); !ok {
+			err = BindingMissingError{ty: field.key.ty, name: field.key.name}
+		} else {
+			svc, err = c.tryGet(field.key.ty, field.key.name, chain)
 		}
 
-		name := ""
-		opts, ok := getTagAsMap(field, "dino")
-		if ok {
-			prop, ok := opts["named"]
-			if ok {
-				name = prop
+		// Only the missing binding of the field itself makes it optional,
+		// not the ones missing further down the chain
+		if _, ok := err.(BindingMissingError); ok {
+			if field.ifBound {
+				continue
+			}
+
+			var provided bool
+			if svc, provided, err = c.provideMissing(field.key); !provided {
+				if !plan.in || field.optional {
+					continue
+				}
+				err = c.bindingMissing(field.key.ty, field.key.name)
 			}
 		}
 
-		svc, err := c.tryGet(fieldType, name, chain)
-		if err == nil {
-			fieldValue.Set(svc)
-		} else if !errors.As(err, &BindingMissingError{}) {
-			return err
+		if err != nil {
+			fieldErr := newFieldError(value.Type(), element.Type().Field(field.index).Name, field.key, chain, err)
+			if !plan.in {
+				return fieldErr
+			}
+			errs = append(errs, fieldErr)
+			continue
 		}
+		fieldValue.Set(svc)
 	}
 
+	if len(errs) > 0 {
+		return FieldsError{ty: value.Type(), errs: errs}
+	}
 	return nil
 }
+
+// FieldError occurs when a service cannot be injected into a field of a struct.
+//
+// It wraps the reason, which is another FieldError if the service failed to have its own fields injected,
+// so that the message describes the whole path, eg. "*app.Server.Store (named:main) → *db.Store: binding missing".
+type FieldError struct {
+	owner reflect.Type
+	field string
+	key   bindingKey
+	err   error
+}
+
+// newFieldError wraps an error, which happened while injecting a field of a struct at the end of the chain.
+func newFieldError(owner reflect.Type, field string, key bindingKey, chain []DepLink, err error) FieldError {
+	// The path to the field is already described by the FieldError,
+	// so the factory error only needs to describe what happened after it
+	if inner, ok := err.(ResolveError); ok && len(inner.chain) > len(chain) {
+		err = ResolveError{chain: inner.chain[len(chain):], err: inner.err}
+	}
+	return FieldError{owner: owner, field: field, key: key, err: err}
+}
+
+// Owner returns the type of the struct the field belongs to, usually a pointer.
+func (e FieldError) Owner() reflect.Type {
+	return e.owner
+}
+
+// Field returns the name of the field.
+func (e FieldError) Field() string {
+	return e.field
+}
+
+// Type returns the type of the service that was supposed to be injected.
+func (e FieldError) Type() reflect.Type {
+	return e.key.ty
+}
+
+// Name returns the namespace the service was requested from.
+func (e FieldError) Name() string {
+	return e.key.name
+}
+
+func (e FieldError) Unwrap() error {
+	return e.err
+}
+
+func (e FieldError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.owner.String())
+	b.WriteString(".")
+	b.WriteString(e.field)
+	if e.key.name != "" {
+		b.WriteString(" (named:")
+		b.WriteString(e.key.name)
+		b.WriteString(")")
+	}
+	b.WriteString(" → ")
+
+	if inner, ok := e.err.(FieldError); ok {
+		b.WriteString(inner.Error())
+		return b.String()
+	}
+
+	b.WriteString(e.key.ty.String())
+	last, cause := e.key, e.err
+	if inner, ok := e.err.(ResolveError); ok {
+		// The first link of the path is the field itself
+		for _, link := range inner.chain[1:] {
+			b.WriteString(" → ")
+			b.WriteString(formatKey(link.ty, link.name))
+			last = bindingKey{ty: link.ty, name: link.name}
+		}
+		cause = inner.err
+	}
+
+	if missing, ok := cause.(BindingMissingError); ok {
+		if missing.ty != last.ty || missing.name != last.name {
+			b.WriteString(" → ")
+			b.WriteString(formatKey(missing.ty, missing.name))
+		}
+		b.WriteString(": binding missing")
+		b.WriteString(formatSuggestions(missing.Suggestions()))
+		return b.String()
+	}
+
+	b.WriteString(": ")
+	b.WriteString(cause.Error())
+	return b.String()
+}
+
+// isOptional checks whether a field of a struct embedding In can be left empty.
+func isOptional(field reflect.StructField) bool {
+	opts, _ := getTagAsMap(field, "dino")
+	_, ok := opts["optional"]
+	return ok
+}
+
+// getServiceName returns the namespace a field should be injected from.
+func getServiceName(field reflect.StructField) string {
+	name := ""
+	opts, ok := getTagAsMap(field, "dino")
+	if ok {
+		prop, ok := opts["named"]
+		if ok {
+			name = prop
+		}
+	}
+	return name
+}
@@ -3,6 +3,7 @@ package dino
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -117,3 +118,186 @@ func TestInjectingNamedSucceeds(t *testing.T) {
 	assert.Nil(t, consumer.D)
 	assert.Equal(t, 4, consumer.E.bar)
 }
+
+func TestInjectingNonStructServicesSucceeds(t *testing.T) {
+	type (
+		Port  int
+		Clock func() int
+	)
+
+	c := &Container{}
+	assert.Nil(t, AddInstance[Port](c, 8080))
+	assert.Nil(t, AddInstance[Clock](c, func() int { return 4 }))
+	assert.Nil(t, AddInstanceNamed[[]string](c, "hosts", []string{"a", "b"}))
+
+	consumer := &struct {
+		Port   Port           `dino:""`
+		Clock  Clock          `dino:""`
+		Hosts  []string       `dino:"named:hosts"`
+		Preset Port           `dino:""`
+		Unset  map[string]int `dino:""`
+	}{
+		Preset: 443,
+	}
+
+	err := injectFields(reflect.ValueOf(consumer), c, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, Port(8080), consumer.Port)
+	assert.Equal(t, 4, consumer.Clock())
+	assert.Equal(t, []string{"a", "b"}, consumer.Hosts)
+	assert.Equal(t, Port(443), consumer.Preset)
+	assert.Nil(t, consumer.Unset)
+}
+
+func TestInjectingSkipsUntaggedValues(t *testing.T) {
+	type settings struct {
+		Name    string
+		Timeout time.Duration
+		Port    int `dino:""`
+	}
+
+	c := &Container{}
+	assert.Nil(t, AddInstance[string](c, "leaked"))
+	assert.Nil(t, AddInstance[time.Duration](c, time.Hour))
+	assert.Nil(t, AddInstance[int](c, 8080))
+	assert.Nil(t, AddTransient[*settings, settings](c))
+
+	s := MustGet[*settings](c)
+	assert.Equal(t, "", s.Name)
+	assert.Equal(t, time.Duration(0), s.Timeout)
+	assert.Equal(t, 8080, s.Port)
+}
+
+func TestInjectingStructValuesSucceeds(t *testing.T) {
+	type (
+		Config struct {
+			Env string
+		}
+		Deps struct {
+			Count int
+		}
+	)
+
+	c := &Container{}
+	assert.Nil(t, AddInstance[Config](c, Config{Env: "prod"}))
+	assert.Nil(t, AddTransient[Deps, Deps](c))
+
+	consumer := &struct {
+		Cfg    Config
+		Deps   Deps
+		Preset Config
+	}{
+		Preset: Config{Env: "test"},
+	}
+
+	err := injectFields(reflect.ValueOf(consumer), c, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "prod", consumer.Cfg.Env)
+	assert.Equal(t, Deps{}, consumer.Deps)
+	assert.Equal(t, "test", consumer.Preset.Env)
+}
+
+func TestInjectingUntaggedStructsOnlyWhenRegistered(t *testing.T) {
+	type (
+		Config struct {
+			Env string
+		}
+		Limits struct {
+			Max int
+		}
+		service struct {
+			Cfg    Config
+			Limits Limits
+		}
+		params struct {
+			In
+			Cfg    Config
+			Limits Limits
+		}
+	)
+
+	for _, seal := range []bool{false, true} {
+		c := &Container{}
+		assert.Nil(t, AddInstance[Config](c, Config{Env: "prod"}))
+		assert.Nil(t, AddTransient[*service, service](c))
+		c.SetMissingHandler(func(ty reflect.Type, name string) (reflect.Value, bool) {
+			return reflect.ValueOf(Limits{Max: 1}), ty == getType[Limits]()
+		})
+		if seal {
+			assert.Nil(t, c.Seal())
+		}
+
+		svc, err := Get[*service](c)
+		assert.Nil(t, err)
+		assert.Equal(t, "prod", svc.Cfg.Env)
+		assert.Equal(t, Limits{}, svc.Limits)
+
+		p, err := Get[params](c)
+		assert.Nil(t, err)
+		assert.Equal(t, "prod", p.Cfg.Env)
+		assert.Equal(t, Limits{}, p.Limits)
+	}
+}
+
+func TestInjectionErrorsDescribeFieldPath(t *testing.T) {
+	type (
+		conn struct{}
+		db   struct{}
+		repo struct {
+			DB *db `dino:"named:accounts"`
+		}
+		controller struct {
+			Repo *repo
+		}
+	)
+
+	c := &Container{}
+	assert.Nil(t, AddFactoryNamed(c, "accounts", func(c *Container) (*db, error) {
+		_, err := Get[*conn](c)
+		return &db{}, err
+	}))
+	assert.Nil(t, Add[*repo, repo](c))
+	assert.Nil(t, Add[*controller, controller](c))
+
+	_, err := Get[*controller](c)
+	assert.EqualError(t, err, "*dino.controller.Repo → *dino.repo.DB (named:accounts) → *dino.db → *dino.conn: binding missing")
+	assert.ErrorIs(t, err, ErrBindingMissing)
+
+	var fieldErr FieldError
+	assert.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, getType[*controller](), fieldErr.Owner())
+	assert.Equal(t, "Repo", fieldErr.Field())
+	assert.Equal(t, getType[*repo](), fieldErr.Type())
+	assert.Equal(t, "", fieldErr.Name())
+
+	var innerErr FieldError
+	assert.ErrorAs(t, fieldErr.Unwrap(), &innerErr)
+	assert.Equal(t, getType[*repo](), innerErr.Owner())
+	assert.Equal(t, "DB", innerErr.Field())
+	assert.Equal(t, "accounts", innerErr.Name())
+}
+
+func TestInjectionSkipsOnlyOwnMissingBindings(t *testing.T) {
+	type (
+		conn struct{}
+		db   struct{}
+		repo struct {
+			DB *db `dino:"named:accounts"`
+		}
+	)
+
+	c := &Container{}
+	assert.Nil(t, Add[*repo, repo](c))
+
+	r, err := Get[*repo](c)
+	assert.Nil(t, err)
+	assert.Nil(t, r.DB)
+
+	assert.Nil(t, AddFactoryNamed(c, "accounts", func(c *Container) (*db, error) {
+		return nil, BindingMissingError{ty: getType[*conn]()}
+	}))
+	assert.Nil(t, AddTransient[*repo, repo](c))
+
+	_, err = Get[*repo](c)
+	assert.EqualError(t, err, "*dino.repo.DB (named:accounts) → *dino.db → *dino.conn: binding missing")
+}
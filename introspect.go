@@ -0,0 +1,130 @@
+package dino
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BindingInfo describes a binding registered in a container.
+type BindingInfo struct {
+	Type    reflect.Type // Type the binding is registered as.
+	Name    string       // Namespace the binding is registered under.
+	Kind    string       // Kind of the binding, eg. "singleton" or "alias".
+	Details string       // Human-readable details about the binding.
+}
+
+func (i BindingInfo) String() string {
+	var b strings.Builder
+	b.WriteString(formatKey(i.Type, i.Name))
+	b.WriteString(" [")
+	b.WriteString(i.Kind)
+	b.WriteString("]")
+	if i.Details != "" {
+		b.WriteString(" ")
+		b.WriteString(i.Details)
+	}
+	return b.String()
+}
+
+// describer is implemented by bindings that can describe themselves for introspection.
+type describer interface {
+	describe(c *Container) string
+}
+
+func (b *singletonBinding) describe(_ *Container) string {
+	details := describeImpl(b.implType, b.factory, b.byValue)
+	if b.isBuilt() {
+		details += " (built)"
+	}
+	return details
+}
+
+func (b *transientBinding) describe(_ *Container) string {
+	return describeImpl(b.implType, b.factory, b.byValue)
+}
+
+func (b *instanceBinding) describe(_ *Container) string {
+	if !b.instance.IsValid() {
+		return "nil"
+	}
+	return "of type " + b.instance.Type().String()
+}
+
+func (b *aliasBinding) describe(_ *Container) string {
+	return "-> " + formatKey(b.targetType, b.targetName)
+}
+
+// describeBinding describes a binding together with its kind.
+func describeBinding(c *Container, binding Binding) string {
+	description := "[" + bindingKind(binding) + "]"
+	if d, ok := binding.(describer); ok {
+		if details := d.describe(c); details != "" {
+			description += " " + details
+		}
+	}
+	return description
+}
+
+// describeImpl describes how a singleton or a transient constructs its service.
+func describeImpl(implType reflect.Type, factory factoryFunc, byValue bool) string {
+	if factory != nil {
+		return "from factory"
+	} else if byValue {
+		return "as " + implType.String()
+	}
+	return "as " + reflect.PointerTo(implType).String()
+}
+
+// formatKey describes a type-name pair as a human-readable string.
+func formatKey(ty reflect.Type, name string) string {
+	typeName := "???"
+	if ty != nil {
+		typeName = ty.String()
+	}
+
+	if name == "" {
+		return typeName
+	}
+	return typeName + " (named:" + name + ")"
+}
+
+// Bindings returns descriptions of all the bindings registered in the container,
+// sorted by their type and name.
+func (c *Container) Bindings() []BindingInfo {
+	c = c.unwrap()
+	infos := make([]BindingInfo, 0)
+	c.m.Range(func(key, value any) bool {
+		ty := key.(reflect.Type)
+		value.(*sync.Map).Range(func(key, value any) bool {
+			binding, ok := value.(Binding)
+			if !ok {
+				return true
+			}
+
+			info := BindingInfo{
+				Type: ty,
+				Name: key.(string),
+				Kind: bindingKind(binding),
+			}
+			if d, ok := binding.(describer); ok {
+				info.Details = d.describe(c)
+			}
+
+			infos = append(infos, info)
+			return true
+		})
+		return true
+	})
+
+	sort.Slice(infos, func(i, j int) bool {
+		ti, tj := infos[i].Type.String(), infos[j].Type.String()
+		if ti != tj {
+			return ti < tj
+		}
+		return infos[i].Name < infos[j].Name
+	})
+
+	return infos
+}
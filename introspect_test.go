@@ -0,0 +1,37 @@
+package dino
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindingsAreDescribed(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[myInterface1, myStruct1](c))
+	assert.Nil(t, AddTransientNamed[myStruct1, myStruct1](c, "copy"))
+	assert.Nil(t, AddInstanceNamed[*myStruct1](c, "instance", &myStruct1{}))
+	assert.Nil(t, AliasNamed[*myStruct1, *myStruct1](c, "primary", "instance"))
+	assert.Nil(t, AddFactoryNamed(c, "answer", func(c *Container) (int, error) { return 42, nil }))
+
+	_, err := Get[myInterface1](c)
+	assert.Nil(t, err)
+
+	infos := c.Bindings()
+	descriptions := make([]string, 0, len(infos))
+	for _, info := range infos {
+		descriptions = append(descriptions, info.String())
+	}
+
+	assert.Equal(t, []string{
+		"*dino.myStruct1 (named:instance) [instance] of type *dino.myStruct1",
+		"*dino.myStruct1 (named:primary) [alias] -> *dino.myStruct1 (named:instance)",
+		"dino.myInterface1 [singleton] as *dino.myStruct1 (built)",
+		"dino.myStruct1 (named:copy) [transient] as dino.myStruct1",
+		"int (named:answer) [singleton] from factory",
+	}, descriptions)
+}
+
+func TestEmptyContainerHasNoBindings(t *testing.T) {
+	assert.Empty(t, (&Container{}).Bindings())
+}
@@ -0,0 +1,132 @@
+package dino
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// InvokeOption changes how Invoke resolves arguments of a function.
+type InvokeOption struct {
+	index int
+	name  string
+}
+
+// ArgNamed makes Invoke resolve the parameter at a provided index (counting from 0)
+// under a provided namespace, instead of the global one.
+func ArgNamed(index int, name string) InvokeOption {
+	return InvokeOption{index: index, name: name}
+}
+
+// Invoke calls a function, resolving each of its parameters from the container.
+//
+// If the last result of the function is an error, Invoke returns it. Other results are discarded.
+// If a parameter cannot be resolved, the function does not get called
+// and Invoke returns an ArgumentError.
+func Invoke(c *Container, fn any, opts ...InvokeOption) error {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return NotFuncError{ty: reflect.TypeOf(fn)}
+	}
+
+	ty := v.Type()
+	names := make([]string, ty.NumIn())
+	for _, opt := range opts {
+		if opt.index < 0 || opt.index >= len(names) {
+			return ArgumentError{fn: ty, index: opt.index, name: opt.name, err: ErrNoSuchParameter}
+		}
+		names[opt.index] = opt.name
+	}
+
+	args := make([]reflect.Value, len(names))
+	for i, name := range names {
+		arg, err := GetValue(c, ty.In(i), name)
+		if err != nil {
+			return ArgumentError{fn: ty, index: i, name: name, err: err}
+		}
+		args[i] = arg
+	}
+
+	var results []reflect.Value
+	if ty.IsVariadic() {
+		results = v.CallSlice(args)
+	} else {
+		results = v.Call(args)
+	}
+
+	if n := len(results); n > 0 && ty.Out(n-1) == errorType {
+		err, _ := results[n-1].Interface().(error)
+		return err
+	}
+	return nil
+}
+
+// errorType is the type of error interface.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// ErrNoSuchParameter is wrapped by ArgumentError, when an option refers to a parameter that does not exist.
+var ErrNoSuchParameter = errors.New("function does not have such a parameter")
+
+// NotFuncError occurs when a user wants to invoke something that is not a function.
+type NotFuncError struct {
+	ty reflect.Type
+}
+
+// Type returns the type of the value the user wanted to invoke, or nil for nil values.
+func (e NotFuncError) Type() reflect.Type {
+	return e.ty
+}
+
+func (e NotFuncError) Error() string {
+	if e.ty == nil {
+		return "cannot invoke nil"
+	}
+	return "cannot invoke a value of type " + e.ty.String() + ", because it is not a function"
+}
+
+// ArgumentError occurs when an argument of an invoked function cannot be resolved.
+type ArgumentError struct {
+	fn    reflect.Type
+	index int
+	name  string
+	err   error
+}
+
+// Index returns the index of the parameter, counting from 0.
+func (e ArgumentError) Index() int {
+	return e.index
+}
+
+// Type returns the type of the parameter, or nil if the function does not have such a parameter.
+func (e ArgumentError) Type() reflect.Type {
+	if e.index < 0 || e.index >= e.fn.NumIn() {
+		return nil
+	}
+	return e.fn.In(e.index)
+}
+
+// Name returns the namespace the argument was requested from.
+func (e ArgumentError) Name() string {
+	return e.name
+}
+
+func (e ArgumentError) Unwrap() error {
+	return e.err
+}
+
+func (e ArgumentError) Error() string {
+	var b strings.Builder
+	b.WriteString("argument ")
+	b.WriteString(strconv.Itoa(e.index))
+	if ty := e.Type(); ty != nil {
+		b.WriteString(" (")
+		b.WriteString(formatKey(ty, e.name))
+		b.WriteString(")")
+	}
+	b.WriteString(" of ")
+	b.WriteString(e.fn.String())
+	b.WriteString(": ")
+	b.WriteString(e.err.Error())
+	return b.String()
+}
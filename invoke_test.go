@@ -0,0 +1,90 @@
+package dino
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvokeResolvesArguments(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[clock, realClock](c))
+	assert.Nil(t, AddNamed[clock, fakeClock](c, "fake"))
+	assert.Nil(t, Add[*scheduler, scheduler](c))
+
+	called := false
+	err := Invoke(c, func(s *scheduler, real clock, fake clock) {
+		called = true
+		assert.Equal(t, 1, s.Clock.Now())
+		assert.Equal(t, 1, real.Now())
+		assert.Equal(t, 2, fake.Now())
+	}, ArgNamed(2, "fake"))
+
+	assert.Nil(t, err)
+	assert.True(t, called)
+}
+
+func TestInvokeReturnsErrorsOfFunctions(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[clock, realClock](c))
+
+	err := Invoke(c, func(clock) error { return assert.AnError })
+	assert.ErrorIs(t, err, assert.AnError)
+
+	err = Invoke(c, func(clock) (int, error) { return 1, nil })
+	assert.Nil(t, err)
+
+	err = Invoke(c, func() int { return 1 })
+	assert.Nil(t, err)
+
+	assert.Panics(t, func() { MustInvoke(c, func() error { return assert.AnError }) })
+}
+
+func TestInvokeFailsWithoutCalling(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[clock, realClock](c))
+
+	called := false
+	fn := func(c clock, s *scheduler) {
+		called = true
+	}
+
+	err := Invoke(c, fn)
+	assert.ErrorIs(t, err, ErrBindingMissing)
+	assert.EqualError(t, err, "argument 1 (*dino.scheduler) of func(dino.clock, *dino.scheduler): "+
+		"container did not have any info about type *dino.scheduler in global namespace")
+
+	var argErr ArgumentError
+	assert.True(t, errors.As(err, &argErr))
+	assert.Equal(t, 1, argErr.Index())
+	assert.Equal(t, getType[*scheduler](), argErr.Type())
+
+	err = Invoke(c, fn, ArgNamed(2, "fake"))
+	assert.ErrorIs(t, err, ErrNoSuchParameter)
+	assert.True(t, errors.As(err, &argErr))
+	assert.Equal(t, 2, argErr.Index())
+	assert.Nil(t, argErr.Type())
+	assert.Equal(t, "fake", argErr.Name())
+	assert.False(t, called)
+}
+
+func TestInvokeRejectsNonFunctions(t *testing.T) {
+	c := &Container{}
+	assert.ErrorAs(t, Invoke(c, 42), &NotFuncError{})
+	assert.EqualError(t, Invoke(c, nil), "cannot invoke nil")
+
+	var fn func()
+	assert.ErrorAs(t, Invoke(c, fn), &NotFuncError{})
+}
+
+func TestInvokeVariadicFunctions(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AddInstance[[]string](c, []string{"a", "b"}))
+
+	var got []string
+	assert.Nil(t, Invoke(c, func(values ...string) {
+		got = values
+	}))
+	assert.Equal(t, []string{"a", "b"}, got)
+}
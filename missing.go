@@ -0,0 +1,76 @@
+package dino
+
+import (
+	"reflect"
+	"sync"
+)
+
+// MissingHandler provides services for fields that do not have any bindings in a container.
+//
+// It gets the type and name of the missing service. If it returns false or an invalid value,
+// the field is left as it is, just like without a handler.
+type MissingHandler func(ty reflect.Type, name string) (svc reflect.Value, ok bool)
+
+// SetMissingHandler sets a function that provides services for fields of constructed structs,
+// which do not have any bindings in the container. Passing nil removes the handler.
+//
+// Services requested directly with Get are not affected. This is meant mainly for tests.
+func (c *Container) SetMissingHandler(h MissingHandler) {
+	c = c.unwrap()
+	c.missing.Store(missingHolder{h: h, set: true})
+}
+
+// ReplaceMissingHandler sets a handler of missing services, just like SetMissingHandler,
+// and returns a function that restores the previous one.
+//
+// If the container did not have a handler of its own before, restoring clears it,
+// so that a child uses the handler of its parent again.
+func (c *Container) ReplaceMissingHandler(h MissingHandler) (restore func()) {
+	c = c.unwrap()
+	previous, _ := c.missing.Swap(missingHolder{h: h, set: true}).(missingHolder)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.missing.Store(previous)
+		})
+	}
+}
+
+// missingHolder stores the handler of missing services of a container.
+// Set tells a handler removed with SetMissingHandler apart from a cleared one,
+// which makes children use the handlers of their parents.
+type missingHolder struct {
+	h   MissingHandler
+	set bool
+}
+
+// missingHandler returns the handler of missing services, if one has been set.
+// Children without handlers of their own use the handlers of their parents.
+func (c *Container) missingHandler() MissingHandler {
+	v, _ := c.missing.Load().(missingHolder)
+	if !v.set && c.parent != nil {
+		return c.parent.missingHandler()
+	}
+
+	return v.h
+}
+
+// provideMissing asks the handler of missing services for a service.
+func (c *Container) provideMissing(key bindingKey) (reflect.Value, bool, error) {
+	h := c.missingHandler()
+	if h == nil {
+		return reflect.Value{}, false, nil
+	}
+
+	svc, ok := h(key.ty, key.name)
+	if !ok || !svc.IsValid() {
+		return reflect.Value{}, false, nil
+	}
+
+	if !svc.Type().AssignableTo(key.ty) {
+		return reflect.Value{}, true, InvalidTypeError{name: key.name, expected: key.ty, actual: svc.Type()}
+	}
+
+	return svc, true, nil
+}
@@ -0,0 +1,80 @@
+package dino
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type notifier struct {
+	Clock  clock
+	Format func(int) string `dino:"named:format"`
+	Count  int
+}
+
+func TestMissingHandlerFillsFields(t *testing.T) {
+	for _, seal := range []bool{false, true} {
+		c := &Container{}
+		assert.Nil(t, AddTransient[*notifier, notifier](c))
+
+		var requested []string
+		c.SetMissingHandler(func(ty reflect.Type, name string) (reflect.Value, bool) {
+			requested = append(requested, ty.String()+"/"+name)
+			if ty == reflect.TypeOf((*clock)(nil)).Elem() {
+				return reflect.ValueOf(fakeClock{}), true
+			}
+			return reflect.Value{}, false
+		})
+		if seal {
+			assert.Nil(t, c.Seal())
+		}
+
+		n := MustGet[*notifier](c)
+		assert.Equal(t, 2, n.Clock.Now())
+		assert.Nil(t, n.Format)
+		assert.Equal(t, []string{"dino.clock/", "func(int) string/format"}, requested)
+
+		// Services requested directly are not affected
+		_, err := Get[clock](c)
+		assert.ErrorAs(t, err, &BindingMissingError{})
+
+		c.SetMissingHandler(nil)
+		assert.Nil(t, MustGet[*notifier](c).Clock)
+	}
+}
+
+func TestMissingHandlerWrongType(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AddTransient[*notifier, notifier](c))
+	c.SetMissingHandler(func(ty reflect.Type, name string) (reflect.Value, bool) {
+		return reflect.ValueOf("not a service"), true
+	})
+
+	_, err := Get[*notifier](c)
+	assert.ErrorAs(t, err, &InvalidTypeError{})
+}
+
+func TestReplaceMissingHandler(t *testing.T) {
+	handler := func(svc clock) MissingHandler {
+		return func(ty reflect.Type, name string) (reflect.Value, bool) {
+			return reflect.ValueOf(svc), true
+		}
+	}
+
+	c := &Container{}
+	assert.Nil(t, AddTransient[*scheduler, scheduler](c))
+	c.SetMissingHandler(handler(realClock{}))
+
+	restore := c.ReplaceMissingHandler(handler(fakeClock{}))
+	assert.Equal(t, 2, MustGet[*scheduler](c).Clock.Now())
+	restore()
+	assert.Equal(t, 1, MustGet[*scheduler](c).Clock.Now())
+
+	// Restoring a child without a handler of its own makes it use the handler of its parent again
+	child := c.NewChild()
+	restore = child.ReplaceMissingHandler(nil)
+	assert.Nil(t, MustGet[*scheduler](child).Clock)
+	restore()
+	assert.Equal(t, 1, MustGet[*scheduler](child).Clock.Now())
+}
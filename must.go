@@ -35,6 +35,14 @@ func MustAddTransientNamed[T any, TImpl any](c *Container, name string) {
 	must(AddTransientNamed[T, TImpl](c, name))
 }
 
+// MustAddAs registers a service of type *TImpl as a singleton in the provided container
+// and makes the same instance available as every type described by the options.
+//
+// If the operation fails, this method will panic.
+func MustAddAs[TImpl any](c *Container, as ...AsOption) {
+	must(AddAs[TImpl](c, as...))
+}
+
 // AddInstance registers an object of type TImpl as a service of type T
 // in the container under a global namespace.
 //
@@ -51,6 +59,56 @@ func MustAddInstanceNamed[T any, TImpl any](c *Container, name string, instance
 	must(AddInstanceNamed[T](c, name, instance))
 }
 
+// MustAddFactory registers a service of type T as a singleton in the provided container.
+//
+// If the operation fails, this method will panic.
+func MustAddFactory[T any](c *Container, factory func(c *Container) (T, error)) {
+	must(AddFactory(c, factory))
+}
+
+// MustAddFactoryNamed registers a service of type T as a singleton in the provided container.
+//
+// If the operation fails, this method will panic.
+func MustAddFactoryNamed[T any](c *Container, name string, factory func(c *Container) (T, error)) {
+	must(AddFactoryNamed(c, name, factory))
+}
+
+// MustAddTransientFactory registers a service of type T as a transient in the provided container.
+//
+// If the operation fails, this method will panic.
+func MustAddTransientFactory[T any](c *Container, factory func(c *Container) (T, error)) {
+	must(AddTransientFactory(c, factory))
+}
+
+// MustAddTransientFactoryNamed registers a service of type T as a transient in the provided container.
+//
+// If the operation fails, this method will panic.
+func MustAddTransientFactoryNamed[T any](c *Container, name string, factory func(c *Container) (T, error)) {
+	must(AddTransientFactoryNamed(c, name, factory))
+}
+
+// MustAddIf registers services only if the provided condition is met.
+//
+// If the operation fails, this method will panic.
+func MustAddIf(c *Container, cond Condition, register func(c *Container) error) {
+	must(AddIf(c, cond, register))
+}
+
+// MustAlias makes a service of type TFrom available as type TTo in a global namespace.
+//
+// If the operation fails, this method will panic.
+func MustAlias[TTo any, TFrom any](c *Container) {
+	must(Alias[TTo, TFrom](c))
+}
+
+// MustAliasNamed makes a service of type TFrom registered under the target namespace
+// available as type TTo under a provided namespace.
+//
+// If the operation fails, this method will panic.
+func MustAliasNamed[TTo any, TFrom any](c *Container, name string, targetName string) {
+	must(AliasNamed[TTo, TFrom](c, name, targetName))
+}
+
 // MustGet tries to create, retrieve or inject an object of type T.
 //
 // If the operation fails, this method will panic.
@@ -68,3 +126,42 @@ func MustGetNamed[T any](c *Container, name string) T {
 	must(err)
 	return svc
 }
+
+// MustGetForTenant tries to create, retrieve or inject an object of type T from the scope of a tenant.
+//
+// If the operation fails, this method will panic.
+func MustGetForTenant[T any](tc *TenantContainer, tenantID string) T {
+	svc, err := GetForTenant[T](tc, tenantID)
+	must(err)
+	return svc
+}
+
+// MustGetNamedForTenant tries to create, retrieve or inject an object of type T from the scope of a tenant.
+//
+// If the operation fails, this method will panic.
+func MustGetNamedForTenant[T any](tc *TenantContainer, tenantID string, name string) T {
+	svc, err := GetNamedForTenant[T](tc, tenantID, name)
+	must(err)
+	return svc
+}
+
+// MustInvoke calls a function, resolving each of its parameters from the container.
+//
+// If the operation or the function fails, this method will panic.
+func MustInvoke(c *Container, fn any, opts ...InvokeOption) {
+	must(Invoke(c, fn, opts...))
+}
+
+// MustResolve fills fields of a struct embedding In with services from the container.
+//
+// If the operation fails, this method will panic.
+func MustResolve(c *Container, target any) {
+	must(Resolve(c, target))
+}
+
+// MustAddBundle registers each field of a struct embedding Out as a separate service in the provided container.
+//
+// If the operation fails, this method will panic.
+func MustAddBundle(c *Container, bundle any) {
+	must(AddBundle(c, bundle))
+}
@@ -19,6 +19,7 @@ func TestMustSucceedAndWorkNormally(t *testing.T) {
 	assert.NotPanics(t, func() { MustAddNamed[*foo, foo](c, "one") })
 	assert.NotPanics(t, func() { MustAddTransient[*foo, foo](c) })
 	assert.NotPanics(t, func() { MustAddTransientNamed[*foo, foo](c, "two") })
+	assert.NotPanics(t, func() { MustAddAs[foo](c, As[iface]()) })
 	assert.NotPanics(t, func() { MustAddInstance[*foo](c, &foo{Bar: 99}) })
 	assert.NotPanics(t, func() { MustAddInstanceNamed[*foo](c, "three", &foo{Bar: 3}) })
 
@@ -32,11 +33,12 @@ func TestMustPanicsNormally(t *testing.T) {
 
 	c := &Container{}
 	assert.Panics(t, func() { MustAdd[*iface, foo](c) })
-	assert.Panics(t, func() { MustAddNamed[foo, foo](c, "one") })
+	assert.Panics(t, func() { MustAddNamed[int, foo](c, "one") })
 	assert.Panics(t, func() { MustAddTransient[*foo, iface](c) })
 	assert.Panics(t, func() { MustAddTransientNamed[*foo, *iface](c, "two") })
 	assert.Panics(t, func() { MustAddInstance[*iface](c, &foo{Bar: 99}) })
 	assert.Panics(t, func() { MustAddInstanceNamed[**foo](c, "three", &foo{Bar: 3}) })
+	assert.Panics(t, func() { MustAddAs[foo](c, As[*iface]()) })
 
 	assert.Panics(t, func() { MustGet[*foo](c) })
 	assert.Panics(t, func() { MustGetNamed[*foo](c, "three") })
@@ -0,0 +1,225 @@
+package dino
+
+import (
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// Lifetime describes how long a service provided by a binding lives.
+type Lifetime int
+
+const (
+	LifetimeUnknown   Lifetime = iota
+	LifetimeSingleton          // Created once and kept by the container.
+	LifetimeTransient          // Created each time it gets requested.
+	LifetimeInstance           // Provided by the user.
+	LifetimeAlias              // Provided by another binding.
+)
+
+func (l Lifetime) String() string {
+	switch l {
+	case LifetimeSingleton:
+		return "singleton"
+	case LifetimeTransient:
+		return "transient"
+	case LifetimeInstance:
+		return "instance"
+	case LifetimeAlias:
+		return "alias"
+	default:
+		return "unknown"
+	}
+}
+
+// lifetimeOf returns the lifetime of services provided by a binding.
+func lifetimeOf(binding Binding) Lifetime {
+	switch binding.(type) {
+	case *singletonBinding:
+		return LifetimeSingleton
+	case *transientBinding:
+		return LifetimeTransient
+	case *instanceBinding:
+		return LifetimeInstance
+	case *aliasBinding:
+		return LifetimeAlias
+	default:
+		return LifetimeUnknown
+	}
+}
+
+// ResolveEvent describes a service being resolved by a container.
+type ResolveEvent struct {
+	ID       uint64        // Unique ID of the resolution.
+	ParentID uint64        // ID of the resolution the service is a dependency of, or 0 if requested directly.
+	Type     reflect.Type  // Type requested from the container.
+	Name     string        // Namespace the type was requested from.
+	Lifetime Lifetime      // Lifetime of the binding used to realize the request.
+	Chain    []DepLink     // Resolutions in progress, ending with this one.
+	Duration time.Duration // Time the resolution or building took, if it has finished.
+	Err      error         // Reason of a failure, for Observer.Error.
+}
+
+// newResolveEvent describes the resolution at the end of a chain.
+func newResolveEvent(chain []DepLink) ResolveEvent {
+	link := chain[len(chain)-1]
+	e := ResolveEvent{
+		ID:       link.id,
+		Type:     link.ty,
+		Name:     link.name,
+		Lifetime: lifetimeOf(link.binding),
+		// The chain gets modified by further resolutions, so observers get their own copy
+		Chain: append([]DepLink(nil), chain...),
+	}
+	if len(chain) > 1 {
+		e.ParentID = chain[len(chain)-2].id
+	}
+	return e
+}
+
+// Observer gets notified about services being resolved by a container.
+//
+// Its methods are called synchronously, possibly from multiple goroutines at once.
+type Observer interface {
+	// ResolveStart is called before a binding gets asked for a service.
+	ResolveStart(e ResolveEvent)
+	// ResolveEnd is called after a service has been resolved successfully.
+	ResolveEnd(e ResolveEvent)
+	// BindingBuilt is called after a singleton or a transient has created a new service.
+	BindingBuilt(e ResolveEvent)
+	// CacheHit is called when a singleton provides a service it has built before.
+	CacheHit(e ResolveEvent)
+	// Error is called instead of ResolveEnd, if resolving a service fails.
+	Error(e ResolveEvent)
+}
+
+// NopObserver ignores all the events.
+// It can be embedded by observers, which are only interested in some of them.
+type NopObserver struct{}
+
+func (NopObserver) ResolveStart(e ResolveEvent) {}
+func (NopObserver) ResolveEnd(e ResolveEvent)   {}
+func (NopObserver) BindingBuilt(e ResolveEvent) {}
+func (NopObserver) CacheHit(e ResolveEvent)     {}
+func (NopObserver) Error(e ResolveEvent)        {}
+
+// MultiObserver returns an observer that notifies all the provided observers, in order.
+func MultiObserver(observers ...Observer) Observer {
+	return multiObserver(append([]Observer(nil), observers...))
+}
+
+type multiObserver []Observer
+
+func (m multiObserver) ResolveStart(e ResolveEvent) {
+	for _, o := range m {
+		o.ResolveStart(e)
+	}
+}
+
+func (m multiObserver) ResolveEnd(e ResolveEvent) {
+	for _, o := range m {
+		o.ResolveEnd(e)
+	}
+}
+
+func (m multiObserver) BindingBuilt(e ResolveEvent) {
+	for _, o := range m {
+		o.BindingBuilt(e)
+	}
+}
+
+func (m multiObserver) CacheHit(e ResolveEvent) {
+	for _, o := range m {
+		o.CacheHit(e)
+	}
+}
+
+func (m multiObserver) Error(e ResolveEvent) {
+	for _, o := range m {
+		o.Error(e)
+	}
+}
+
+// observerHolder allows observers of different types to be stored in the same atomic.Value.
+type observerHolder struct {
+	o Observer
+}
+
+// SetObserver sets an observer, which gets notified about services being resolved by the container.
+// Passing nil removes the observer.
+func (c *Container) SetObserver(o Observer) {
+	c = c.unwrap()
+	c.observed.Store(observerHolder{o: o})
+}
+
+// observer returns the observer of the container, if one has been set.
+// Children without observers of their own use the observers of their parents.
+func (c *Container) observer() Observer {
+	// Bindings can be asked for services without a container
+	if c == nil {
+		return nil
+	}
+
+	v := c.observed.Load()
+	if v == nil && c.parent != nil {
+		return c.parent.observer()
+	}
+
+	h, _ := v.(observerHolder)
+	return h.o
+}
+
+// resolveIDs generates IDs of observed resolutions.
+var resolveIDs uint64
+
+// provideObserved asks a binding to provide a service, notifying an observer about it.
+func (c *Container) provideObserved(o Observer, ty reflect.Type, name string, b Binding, chain []DepLink) (reflect.Value, error) {
+	chain = append(chain, DepLink{ty: ty, name: name, binding: b, id: atomic.AddUint64(&resolveIDs, 1)})
+	e := newResolveEvent(chain)
+	o.ResolveStart(e)
+
+	start := time.Now()
+	svc, err := b.Provide(c, chain)
+	e.Duration = time.Since(start)
+
+	if err != nil {
+		e.Err = err
+		o.Error(e)
+	} else {
+		o.ResolveEnd(e)
+	}
+	return svc, err
+}
+
+// observeCacheHit notifies the observer, if there is one,
+// that the binding at the end of the chain has provided a service it has built before.
+func (c *Container) observeCacheHit(chain []DepLink) {
+	if o := c.observer(); o != nil && len(chain) > 0 {
+		o.CacheHit(newResolveEvent(chain))
+	}
+}
+
+// buildTimer measures how long it takes to build a service, if the container is observed.
+type buildTimer struct {
+	o     Observer
+	start time.Time
+}
+
+// startBuild starts measuring how long it takes to build a service.
+func (c *Container) startBuild() buildTimer {
+	o := c.observer()
+	if o == nil {
+		return buildTimer{}
+	}
+	return buildTimer{o: o, start: time.Now()}
+}
+
+// done notifies the observer, if there is one,
+// that the binding at the end of the chain has built a service.
+func (t buildTimer) done(chain []DepLink) {
+	if t.o != nil && len(chain) > 0 {
+		e := newResolveEvent(chain)
+		e.Duration = time.Since(t.start)
+		t.o.BindingBuilt(e)
+	}
+}
@@ -0,0 +1,112 @@
+package dino
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []string
+	byKind map[string][]ResolveEvent
+}
+
+func (o *recordingObserver) record(kind string, e ResolveEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, fmt.Sprintf("%s %s %s", kind, e.Type, e.Lifetime))
+	if o.byKind == nil {
+		o.byKind = make(map[string][]ResolveEvent)
+	}
+	o.byKind[kind] = append(o.byKind[kind], e)
+}
+
+func (o *recordingObserver) ResolveStart(e ResolveEvent) { o.record("start", e) }
+func (o *recordingObserver) ResolveEnd(e ResolveEvent)   { o.record("end", e) }
+func (o *recordingObserver) BindingBuilt(e ResolveEvent) { o.record("built", e) }
+func (o *recordingObserver) CacheHit(e ResolveEvent)     { o.record("hit", e) }
+func (o *recordingObserver) Error(e ResolveEvent)        { o.record("error", e) }
+
+func TestObserverSeesResolution(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AddInstance[clock](c, fakeClock{}))
+	assert.Nil(t, Add[*scheduler, scheduler](c))
+	assert.Nil(t, AliasNamed[*scheduler, *scheduler](c, "main", ""))
+
+	o := &recordingObserver{}
+	c.SetObserver(o)
+
+	MustGetNamed[*scheduler](c, "main")
+	MustGet[*scheduler](c)
+
+	assert.Equal(t, []string{
+		"start *dino.scheduler alias",
+		"start *dino.scheduler singleton",
+		"start dino.clock instance",
+		"end dino.clock instance",
+		"built *dino.scheduler singleton",
+		"end *dino.scheduler singleton",
+		"end *dino.scheduler alias",
+		"start *dino.scheduler singleton",
+		"hit *dino.scheduler singleton",
+		"end *dino.scheduler singleton",
+	}, o.events)
+
+	starts := o.byKind["start"]
+	assert.Equal(t, uint64(0), starts[0].ParentID)
+	assert.Equal(t, starts[0].ID, starts[1].ParentID)
+	assert.Equal(t, starts[1].ID, starts[2].ParentID)
+	assert.Equal(t, "main", starts[0].Name)
+
+	chain := o.byKind["end"][0].Chain
+	assert.Len(t, chain, 3)
+	assert.Equal(t, "main", chain[0].Name())
+	assert.Equal(t, LifetimeAlias, chain[0].Lifetime())
+	assert.Equal(t, "dino.clock", chain[2].Type().String())
+	assert.Equal(t, LifetimeInstance, chain[2].Lifetime())
+
+	c.SetObserver(nil)
+	MustGet[*scheduler](c)
+	assert.Len(t, o.events, 10)
+}
+
+func TestObserverSeesErrors(t *testing.T) {
+	type loop struct {
+		Self *loop
+	}
+
+	c := &Container{}
+	assert.Nil(t, AddTransient[*loop, loop](c))
+
+	first, second := &recordingObserver{}, &recordingObserver{}
+	c.SetObserver(MultiObserver(first, second))
+
+	_, err := Get[*loop](c)
+	assert.ErrorAs(t, err, &CyclicDependencyError{})
+	assert.Equal(t, first.events, second.events)
+	assert.Len(t, first.byKind["error"], 2)
+	assert.Empty(t, first.byKind["end"])
+	assert.Empty(t, first.byKind["built"])
+	assert.Equal(t, err, first.byKind["error"][1].Err)
+}
+
+type builtCounter struct {
+	NopObserver
+	built int
+}
+
+func (o *builtCounter) BindingBuilt(e ResolveEvent) { o.built++ }
+
+func TestObserverEmbeddingNop(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AddTransient[*myStruct1, myStruct1](c))
+
+	o := &builtCounter{}
+	c.SetObserver(o)
+	MustGet[*myStruct1](c)
+	MustGet[*myStruct1](c)
+	assert.Equal(t, 2, o.built)
+}
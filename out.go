@@ -0,0 +1,79 @@
+package dino
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Out marks bundle structs, which group services built together, eg. by a single bootstrap function,
+// so that they can be registered at once with AddBundle:
+//
+//	type Storage struct {
+//		dino.Out
+//		DB       *sql.DB `dino:"named:primary"`
+//		Migrator Migrator
+//	}
+type Out struct{}
+
+var outType = reflect.TypeOf(Out{})
+
+// ErrNotOut is returned by AddBundle, when the struct provided for registration does not embed Out.
+var ErrNotOut = errors.New("struct provided for registration does not embed dino.Out")
+
+// ErrNilField is wrapped by FieldError, when a field of a bundle is nil and not tagged as optional.
+var ErrNilField = errors.New("field of a bundle is nil")
+
+// AddBundle registers fields of a bundle struct as separate instances in the provided container.
+// The fields are the same ones that would get injected: exported interfaces, pointers and structs,
+// and other fields only if they have a dino tag. Each service has the type of its field
+// and gets registered under the namespace from the named option of its tag, just like with AddInstanceNamed.
+//
+// The bundle must be a pointer to a struct embedding Out. Nil interfaces and pointers
+// are skipped, if their fields are tagged as optional, and are errors otherwise.
+// If any field cannot be registered, the error is a FieldError and none of the fields get registered.
+func AddBundle(c *Container, bundle any) error {
+	value := reflect.ValueOf(bundle)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return ErrPtrNotToStruct
+	}
+
+	element := value.Elem()
+	plan := getPlan(element.Type())
+	if !plan.out {
+		return ErrNotOut
+	}
+
+	// Check all the fields first, so that the bundle does not get registered partially
+	bindings := make([]*instanceBinding, len(plan.fields))
+	for i, field := range plan.fields {
+		instance := element.Field(field.index)
+		if (instance.Kind() == reflect.Interface || instance.Kind() == reflect.Pointer) && instance.IsNil() {
+			if field.optional {
+				continue
+			}
+			return FieldError{owner: value.Type(), field: element.Type().Field(field.index).Name, key: field.key, err: ErrNilField}
+		}
+
+		// Interfaces get checked against the implementation they hold
+		if instance.Kind() == reflect.Interface {
+			instance = instance.Elem()
+		}
+
+		binding, err := newInstanceBinding(field.key.ty, instance.Type(), instance)
+		if err != nil {
+			return FieldError{owner: value.Type(), field: element.Type().Field(field.index).Name, key: field.key, err: err}
+		}
+		bindings[i] = binding
+	}
+
+	for i, field := range plan.fields {
+		if bindings[i] == nil {
+			continue
+		}
+		if err := c.store(field.key.ty, field.key.name, bindings[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
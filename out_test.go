@@ -0,0 +1,74 @@
+package dino
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type port int
+
+type clockBundle struct {
+	Out
+	Clock     clock
+	Fake      clock      `dino:"named:fake"`
+	Scheduler *scheduler `dino:"named:main"`
+	Port      port       `dino:""`
+	Sender    mailSender `dino:"optional"`
+	private   *realClock
+}
+
+type invalidBundle struct {
+	Out
+	Clock  clock
+	Target *myInterface1
+}
+
+func TestAddBundleRegistersEachField(t *testing.T) {
+	c := &Container{}
+	bundle := &clockBundle{
+		Clock:     realClock{},
+		Fake:      &fakeClock{},
+		Scheduler: &scheduler{},
+		Port:      8080,
+	}
+	assert.Nil(t, AddBundle(c, bundle))
+
+	assert.Equal(t, 1, MustGet[clock](c).Now())
+	assert.Equal(t, 2, MustGetNamed[clock](c, "fake").Now())
+	assert.Same(t, bundle.Scheduler, MustGetNamed[*scheduler](c, "main"))
+	assert.Equal(t, port(8080), MustGet[port](c))
+	assert.Len(t, c.Bindings(), 4)
+
+	_, err := Get[mailSender](c)
+	assert.ErrorIs(t, err, ErrBindingMissing)
+}
+
+func TestAddBundleRegistersNothingOnError(t *testing.T) {
+	c := &Container{}
+	err := AddBundle(c, &clockBundle{Clock: realClock{}, Scheduler: &scheduler{}})
+	assert.ErrorIs(t, err, ErrNilField)
+	assert.EqualError(t, err, "*dino.clockBundle.Fake (named:fake) → dino.clock: field of a bundle is nil")
+
+	var fieldErr FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "Fake", fieldErr.Field())
+	assert.Equal(t, "fake", fieldErr.Name())
+
+	target := myInterface1(&myStruct1{})
+	err = AddBundle(c, &invalidBundle{Clock: realClock{}, Target: &target})
+	assert.ErrorAs(t, err, &InvalidServiceTypeError{})
+	assert.Empty(t, c.Bindings())
+}
+
+func TestAddBundleRejectsOtherValues(t *testing.T) {
+	c := &Container{}
+	assert.ErrorIs(t, AddBundle(c, clockBundle{}), ErrPtrNotToStruct)
+	assert.ErrorIs(t, AddBundle(c, &scheduler{}), ErrNotOut)
+	assert.Panics(t, func() { MustAddBundle(c, &scheduler{}) })
+
+	assert.Nil(t, c.Seal())
+	err := AddBundle(c, &clockBundle{Clock: realClock{}, Fake: fakeClock{}, Scheduler: &scheduler{}})
+	assert.ErrorAs(t, err, &ContainerSealedError{})
+}
@@ -0,0 +1,131 @@
+package dino
+
+import (
+	"reflect"
+	"sync"
+)
+
+// injectionPlan describes how to inject fields into structs of a specific type.
+//
+// Plans only depend on the type itself, so they get built once and shared by all containers.
+type injectionPlan struct {
+	fields []fieldPlan
+	in     bool // Whether the struct embeds In.
+	out    bool // Whether the struct embeds Out.
+}
+
+// fieldPlan describes a single field that can be injected.
+type fieldPlan struct {
+	index    int        // Index of the field in the struct.
+	key      bindingKey // Type and name of the service to inject.
+	optional bool       // Whether the field can be left empty in structs embedding In.
+	ifBound  bool       // Whether the field is only injected if there is a binding for it, without failing otherwise.
+}
+
+// keys returns type-name pairs of services that would be injected by the plan.
+func (p *injectionPlan) keys() []bindingKey {
+	keys := make([]bindingKey, len(p.fields))
+	for i, field := range p.fields {
+		keys[i] = field.key
+	}
+	return keys
+}
+
+// plans caches injection plans by struct type.
+var plans sync.Map
+
+// getPlan returns an injection plan for a struct type, building it if necessary.
+func getPlan(ty reflect.Type) *injectionPlan {
+	if p, ok := plans.Load(ty); ok {
+		return p.(*injectionPlan)
+	}
+
+	p, _ := plans.LoadOrStore(ty, buildPlan(ty))
+	return p.(*injectionPlan)
+}
+
+// buildPlan inspects a struct type to find all the fields that can be injected.
+func buildPlan(ty reflect.Type) *injectionPlan {
+	fieldCount := ty.NumField()
+	plan := &injectionPlan{
+		fields: make([]fieldPlan, 0, fieldCount),
+	}
+
+	for i := 0; i < fieldCount; i++ {
+
+		// We can only set exported fields
+		field := ty.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		// Markers themselves are not dependencies
+		if field.Anonymous && field.Type == inType {
+			plan.in = true
+			continue
+		}
+		if field.Anonymous && field.Type == outType {
+			plan.out = true
+			continue
+		}
+
+		// Other values, eg. strings or durations, are usually plain settings, so they only get injected when tagged.
+		// Untagged structs get injected as long as a service of exactly their type is registered.
+		_, tagged := field.Tag.Lookup("dino")
+		ifBound := false
+		switch field.Type.Kind() {
+		case reflect.Interface, reflect.Pointer:
+		case reflect.Struct:
+			ifBound = !tagged
+		default:
+			if !tagged {
+				continue
+			}
+		}
+
+		plan.fields = append(plan.fields, fieldPlan{
+			index:    i,
+			key:      bindingKey{ty: field.Type, name: getServiceName(field)},
+			optional: isOptional(field),
+			ifBound:  ifBound,
+		})
+	}
+
+	return plan
+}
+
+// bindPlans resolves bindings for fields of all the types constructed by the sealed bindings,
+// so that injecting them no longer requires looking anything up.
+func (s *sealedBindings) bindPlans() {
+	for _, binding := range s.bindings {
+		var implType reflect.Type
+		switch b := binding.(type) {
+		case *singletonBinding:
+			if b.factory == nil {
+				implType = b.implType
+			}
+		case *transientBinding:
+			if b.factory == nil {
+				implType = b.implType
+			}
+		}
+
+		if implType == nil {
+			continue
+		}
+		if _, ok := s.plans[implType]; ok {
+			continue
+		}
+
+		plan := getPlan(implType)
+		bindings := make([]Binding, len(plan.fields))
+		for i, field := range plan.fields {
+			if b, ok := s.bindings[field.key]; ok {
+				bindings[i] = b
+			} else {
+				bindings[i] = inBinding(field.key.ty, field.key.name)
+			}
+		}
+		s.plans[implType] = bindings
+	}
+}
@@ -0,0 +1,66 @@
+package dino
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanContainsExportedFields(t *testing.T) {
+	type foo struct {
+		A      *myStruct1
+		b      *myStruct1
+		C      myInterface1 `dino:"named:c"`
+		D      int          `dino:"named:d;other"`
+		hidden int
+	}
+
+	plan := getPlan(getType[foo]())
+	assert.Equal(t, []fieldPlan{
+		{index: 0, key: bindingKey{ty: getType[*myStruct1](), name: ""}},
+		{index: 2, key: bindingKey{ty: getType[myInterface1](), name: "c"}},
+		{index: 3, key: bindingKey{ty: getType[int](), name: "d"}},
+	}, plan.fields)
+}
+
+func TestPlanIsCached(t *testing.T) {
+	type foo struct {
+		A *myStruct1
+	}
+
+	assert.Same(t, getPlan(getType[foo]()), getPlan(getType[foo]()))
+}
+
+func TestSealedContainerBindsPlans(t *testing.T) {
+	type foo struct {
+		A *myStruct1
+		B *myStruct1 `dino:"named:b"`
+		C myInterface1
+	}
+
+	c := &Container{}
+	b := &myStruct1{Foo: 2}
+	assert.Nil(t, AddTransient[*foo, foo](c))
+	assert.Nil(t, Add[*myStruct1, myStruct1](c))
+	assert.Nil(t, AddInstanceNamed[*myStruct1](c, "b", b))
+	assert.Nil(t, c.Seal())
+
+	bindings := c.sealedBindings().plans[getType[foo]()]
+	assert.Len(t, bindings, 3)
+	assert.IsType(t, &singletonBinding{}, bindings[0])
+	assert.IsType(t, &instanceBinding{}, bindings[1])
+	assert.Nil(t, bindings[2])
+
+	f, err := Get[*foo](c)
+	assert.Nil(t, err)
+	assert.NotNil(t, f.A)
+	assert.Same(t, b, f.B)
+	assert.Nil(t, f.C)
+
+	// Fields which are not zero still should not get overwritten
+	preset := &foo{A: &myStruct1{Foo: 4}}
+	assert.Nil(t, injectFields(reflect.ValueOf(preset), c, nil))
+	assert.Equal(t, 4, preset.A.Foo)
+	assert.Same(t, b, preset.B)
+}
@@ -0,0 +1,170 @@
+package dino
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Replace replaces the service of type T in a global namespace with a provided instance
+// and returns a function that restores the previous registration.
+func Replace[T any](c *Container, instance T) (restore func(), err error) {
+	return ReplaceNamed(c, "", instance)
+}
+
+// ReplaceNamed replaces the service of type T under a provided namespace with a provided instance
+// and returns a function that restores the previous registration.
+//
+// Singletons that have been built with the previous service, directly or through other services,
+// get rebuilt on their next request, both after replacing and after restoring.
+// Singletons created by factories are left alone, as Dino cannot tell what they depend on.
+// Only singletons of the container itself get rebuilt: the ones already built by its child containers
+// keep the previous service, while clones do not share registrations with the container at all.
+//
+// Sealed containers accept replacements too, eg. clones of a sealed production container in tests,
+// as their snapshots of bindings get updated along with the registrations.
+func ReplaceNamed[T any](c *Container, name string, instance T) (restore func(), err error) {
+	c = c.unwrap()
+	t := getType[T]()
+	if !isValidServiceType(t) {
+		return nil, InvalidServiceTypeError{ty: t}
+	}
+
+	c.registering.Lock()
+	defer c.registering.Unlock()
+
+	key := bindingKey{ty: t, name: name}
+	names := c.getInnerMapOfNames(t)
+	previous, hadPrevious := names.Load(name)
+	var sealedPrevious Binding
+	if s := c.sealedBindings(); s != nil {
+		sealedPrevious = s.bindings[key]
+	}
+
+	// Take the value through a pointer, so that it keeps its static type, even if T is an interface
+	binding := &instanceBinding{instance: reflect.ValueOf(&instance).Elem()}
+	names.Store(name, binding)
+	c.resealWith(key, binding)
+	c.invalidateDependents(key)
+
+	var once sync.Once
+	restore = func() {
+		once.Do(func() {
+			c.registering.Lock()
+			defer c.registering.Unlock()
+			if hadPrevious {
+				names.Store(name, previous)
+			} else {
+				names.Delete(name)
+			}
+			c.resealWith(key, sealedPrevious)
+			c.invalidateDependents(key)
+		})
+	}
+
+	return restore, nil
+}
+
+// resealWith updates the snapshot of bindings of a sealed container,
+// so that a provided binding (or none, if it is nil) is registered under a key.
+// It must be called with the registration lock held.
+func (c *Container) resealWith(key bindingKey, binding Binding) {
+	s := c.sealedBindings()
+	if s == nil {
+		return
+	}
+
+	// Snapshots are read without synchronization, so they get replaced instead of modified
+	resealed := &sealedBindings{
+		bindings:   make(map[bindingKey]Binding, len(s.bindings)),
+		conditions: s.conditions,
+		plans:      make(map[reflect.Type][]Binding, len(s.plans)),
+	}
+	for k, b := range s.bindings {
+		resealed.bindings[k] = b
+	}
+	if binding != nil {
+		resealed.bindings[key] = binding
+	} else {
+		delete(resealed.bindings, key)
+	}
+
+	resealed.bindPlans()
+	c.sealed.Store(resealed)
+}
+
+// invalidateDependents resets singletons that might have been built with the service
+// registered under a provided key, directly or through other services.
+func (c *Container) invalidateDependents(changed bindingKey) {
+	// A single binding might be stored under several keys, eg. when registered with AddAs
+	keysOf := make(map[Binding][]bindingKey)
+	c.m.Range(func(ty, names any) bool {
+		names.(*sync.Map).Range(func(name, value any) bool {
+			if binding, ok := value.(Binding); ok {
+				key := bindingKey{ty: ty.(reflect.Type), name: name.(string)}
+				for _, b := range possibleBindings(binding) {
+					keysOf[b] = append(keysOf[b], key)
+				}
+			}
+			return true
+		})
+		return true
+	})
+
+	visited := map[bindingKey]bool{changed: true}
+	queue := []bindingKey{changed}
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+
+		for binding, keys := range keysOf {
+			if !dependsOn(binding, key) {
+				continue
+			}
+
+			if singleton, ok := binding.(*singletonBinding); ok {
+				singleton.reset()
+			}
+
+			// Anything that got this binding injected might hold onto a stale service as well
+			for _, k := range keys {
+				if !visited[k] {
+					visited[k] = true
+					queue = append(queue, k)
+				}
+			}
+		}
+	}
+}
+
+// possibleBindings returns all the bindings that might end up providing the service,
+// including every candidate of a conditional binding.
+func possibleBindings(binding Binding) []Binding {
+	cb, ok := binding.(*conditionalBinding)
+	if !ok {
+		return []Binding{binding}
+	}
+
+	var bindings []Binding
+	for _, candidate := range cb.candidates {
+		bindings = append(bindings, possibleBindings(candidate.binding)...)
+	}
+	if cb.fallback != nil {
+		bindings = append(bindings, possibleBindings(cb.fallback)...)
+	}
+	return bindings
+}
+
+// dependsOn checks whether a binding might request the service registered under a provided key.
+func dependsOn(binding Binding, key bindingKey) bool {
+	for _, dep := range dependenciesOf(binding) {
+		if dep == key {
+			return true
+		}
+	}
+	return false
+}
+
+// reset discards the instance of the singleton, so that it gets built again on the next request.
+func (b *singletonBinding) reset() {
+	b.instance.Store((*builtInstance)(nil))
+}
@@ -0,0 +1,132 @@
+package dino
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type clock interface {
+	Now() int
+}
+
+type realClock struct{}
+
+func (realClock) Now() int { return 1 }
+
+type fakeClock struct{}
+
+func (fakeClock) Now() int { return 2 }
+
+type scheduler struct {
+	Clock clock
+}
+
+type ticker struct {
+	Clock clock `dino:"named:ticking"`
+}
+
+type app struct {
+	Scheduler *scheduler
+	Ticker    *ticker
+	Other     *myStruct2
+}
+
+func TestReplaceRebuildsDependentSingletons(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[clock, realClock](c))
+	assert.Nil(t, AliasNamed[clock, clock](c, "ticking", ""))
+	assert.Nil(t, Add[*scheduler, scheduler](c))
+	assert.Nil(t, AddTransient[*ticker, ticker](c))
+	assert.Nil(t, Add[*myStruct2, myStruct2](c))
+	assert.Nil(t, Add[*app, app](c))
+
+	before := MustGet[*app](c)
+	real := MustGet[clock](c)
+	assert.Equal(t, 1, before.Scheduler.Clock.Now())
+
+	restore, err := Replace[clock](c, fakeClock{})
+	assert.Nil(t, err)
+
+	during := MustGet[*app](c)
+	assert.NotSame(t, before, during)
+	assert.Equal(t, 2, during.Scheduler.Clock.Now())
+	assert.Equal(t, 2, during.Ticker.Clock.Now())
+	assert.Same(t, before.Other, during.Other)
+
+	restore()
+	restore()
+
+	after := MustGet[*app](c)
+	assert.NotSame(t, during, after)
+	assert.Equal(t, 1, after.Scheduler.Clock.Now())
+	assert.Equal(t, 1, after.Ticker.Clock.Now())
+	assert.Same(t, real, MustGet[clock](c))
+}
+
+func TestReplaceSharedSingleton(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AddAs[myStruct3](c, As[myInterface1](), As[myInterface2]()))
+
+	type consumer struct {
+		Dep myInterface2
+	}
+	assert.Nil(t, Add[*consumer, consumer](c))
+	before := MustGet[*consumer](c)
+
+	fake := &myStruct3{}
+	restore, err := Replace[*myStruct3](c, fake)
+	assert.Nil(t, err)
+
+	// Only the pointer gets replaced, so services built with the interfaces stay the same
+	assert.Same(t, fake, MustGet[*myStruct3](c))
+	assert.Same(t, before.Dep, MustGet[myInterface2](c))
+	assert.Same(t, before, MustGet[*consumer](c))
+
+	restore()
+	assert.Same(t, before.Dep, MustGet[*myStruct3](c))
+}
+
+func TestReplaceMissingBinding(t *testing.T) {
+	c := &Container{}
+
+	restore, err := ReplaceNamed[clock](c, "test", fakeClock{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, MustGetNamed[clock](c, "test").Now())
+
+	restore()
+	_, err = GetNamed[clock](c, "test")
+	assert.ErrorAs(t, err, &BindingMissingError{})
+}
+
+func TestReplaceFails(t *testing.T) {
+	c := &Container{}
+	_, err := Replace[*myInterface1](c, nil)
+	assert.ErrorAs(t, err, &InvalidServiceTypeError{})
+
+}
+
+func TestReplaceInSealedContainer(t *testing.T) {
+	type consumer struct {
+		Clock clock
+	}
+
+	c := &Container{}
+	assert.Nil(t, Add[clock, realClock](c))
+	assert.Nil(t, Add[*consumer, consumer](c))
+	assert.Nil(t, c.Seal())
+	assert.Equal(t, 1, MustGet[*consumer](c).Clock.Now())
+
+	restore, err := Replace[clock](c, fakeClock{})
+	assert.Nil(t, err)
+	assert.True(t, c.IsSealed())
+	assert.Equal(t, 2, MustGet[clock](c).Now())
+	assert.Equal(t, 2, MustGet[*consumer](c).Clock.Now())
+
+	restore()
+	assert.Equal(t, 1, MustGet[clock](c).Now())
+	assert.Equal(t, 1, MustGet[*consumer](c).Clock.Now())
+
+	// Registrations are still rejected
+	assert.ErrorAs(t, Add[*myStruct1, myStruct1](c), &ContainerSealedError{})
+}
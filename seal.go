@@ -0,0 +1,225 @@
+package dino
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// bindingKey identifies a binding in a container.
+type bindingKey struct {
+	ty   reflect.Type
+	name string
+}
+
+// sealedBindings is an immutable snapshot of bindings, created when a container gets sealed.
+type sealedBindings struct {
+	bindings   map[bindingKey]Binding
+	conditions map[*conditionalBinding][]bool // Results of conditions evaluated during sealing.
+	plans      map[reflect.Type][]Binding     // Bindings of fields of injection plans, by struct type.
+}
+
+// sealedBindings returns the snapshot of bindings, if the container has been sealed.
+func (c *Container) sealedBindings() *sealedBindings {
+	s, _ := c.sealed.Load().(*sealedBindings)
+	return s
+}
+
+// IsSealed checks whether the container has been sealed.
+func (c *Container) IsSealed() bool {
+	return c.unwrap().sealedBindings() != nil
+}
+
+// Seal finishes the registration phase of the container.
+//
+// It evaluates conditions of conditional registrations, validates the bindings
+// and takes a snapshot of them, so that resolving services no longer needs to synchronize
+// with registrations. Conditions get evaluated before registrations are locked,
+// so their predicates may register services as well.
+// Registrations attempted while the snapshot is taken wait for it to finish,
+// and every registration attempted after sealing fails with a ContainerSealedError.
+//
+// If the validation fails, the container does not get sealed.
+// Sealing an already sealed container does nothing.
+func (c *Container) Seal() error {
+	c = c.unwrap()
+
+	// Conditions run code of the user, which might even register services,
+	// so they get evaluated before locking registrations. If conditional registrations
+	// get added in the meantime, their conditions are evaluated on the next attempt.
+	results := make(map[*conditionalBinding][]bool)
+	for {
+		if c.IsSealed() {
+			return nil
+		}
+
+		c.m.Range(func(_, value any) bool {
+			value.(*sync.Map).Range(func(_, value any) bool {
+				if cb, ok := value.(*conditionalBinding); ok {
+					cb.evaluateInto(c, results)
+				}
+				return true
+			})
+			return true
+		})
+
+		if sealed, err := c.sealWith(results); sealed || err != nil {
+			return err
+		}
+	}
+}
+
+// sealWith takes a snapshot of the bindings, using recorded results of their conditions, and publishes it.
+// If conditions of some bindings have not been evaluated, it returns false without sealing the container.
+func (c *Container) sealWith(results map[*conditionalBinding][]bool) (bool, error) {
+	// Registrations wait until the snapshot is published, so that none of them gets lost
+	c.registering.Lock()
+	defer c.registering.Unlock()
+	if c.IsSealed() {
+		return true, nil
+	}
+
+	s := &sealedBindings{
+		bindings:   make(map[bindingKey]Binding),
+		conditions: make(map[*conditionalBinding][]bool),
+		plans:      make(map[reflect.Type][]Binding),
+	}
+
+	complete := true
+	c.m.Range(func(key, value any) bool {
+		ty := key.(reflect.Type)
+		value.(*sync.Map).Range(func(key, value any) bool {
+			binding, ok := value.(Binding)
+			if !ok {
+				return true
+			}
+
+			// Each condition gets evaluated only once, so that introspection reports the binding actually used
+			if cb, ok := binding.(*conditionalBinding); ok {
+				if binding, complete = cb.chosen(results); !complete {
+					return false
+				}
+				cb.recordInto(results, s.conditions)
+				if binding == nil {
+					return true
+				}
+			}
+
+			s.bindings[bindingKey{ty: ty, name: key.(string)}] = binding
+			return true
+		})
+		return complete
+	})
+	if !complete {
+		return false, nil
+	}
+
+	if err := s.validate(c); err != nil {
+		return false, err
+	}
+
+	s.bindPlans()
+	c.sealed.Store(s)
+	return true, nil
+}
+
+// validate checks whether all the aliases point to existing bindings
+// and that no service would depend on itself while being created.
+func (s *sealedBindings) validate(c *Container) error {
+	for key, binding := range s.bindings {
+		if alias, ok := binding.(*aliasBinding); ok {
+			target := bindingKey{ty: alias.targetType, name: alias.targetName}
+			if _, ok := s.bindings[target]; !ok && !c.parent.has(target) {
+				return c.bindingMissing(target.ty, target.name)
+			}
+		}
+
+		// Singletons are allowed to depend on themselves,
+		// so cycles are only a problem if they go through a transient or an alias.
+		switch binding.(type) {
+		case *transientBinding, *aliasBinding:
+			chain := []DepLink{{ty: key.ty, name: key.name, binding: binding}}
+			if cycle := s.findCycle(binding, chain, make(map[Binding]bool)); cycle != nil {
+				return CyclicDependencyError{chain: cycle}
+			}
+		}
+	}
+
+	return nil
+}
+
+// findCycle looks for a chain of dependencies leading from the last binding in the chain
+// back to the start binding. Visited contains bindings that have already been checked.
+func (s *sealedBindings) findCycle(start Binding, chain []DepLink, visited map[Binding]bool) []DepLink {
+	current := chain[len(chain)-1].binding
+	if visited[current] {
+		return nil
+	}
+	visited[current] = true
+
+	for _, dep := range dependenciesOf(current) {
+		binding, ok := s.bindings[dep]
+		if !ok {
+			continue
+		}
+
+		next := append(chain[:len(chain):len(chain)], DepLink{ty: dep.ty, name: dep.name, binding: binding})
+		if binding == start {
+			return next
+		}
+		if cycle := s.findCycle(start, next, visited); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+// dependenciesOf returns type-name pairs of services a binding might request from the container.
+func dependenciesOf(binding Binding) []bindingKey {
+	switch b := binding.(type) {
+	case *singletonBinding:
+		if b.factory == nil {
+			return getPlan(b.implType).keys()
+		}
+	case *transientBinding:
+		if b.factory == nil {
+			return getPlan(b.implType).keys()
+		}
+	case *aliasBinding:
+		return []bindingKey{{ty: b.targetType, name: b.targetName}}
+	}
+	return nil
+}
+
+// ContainerSealedError occurs when a user wants to register a service,
+// but the container has already been sealed.
+type ContainerSealedError struct {
+	ty   reflect.Type
+	name string
+}
+
+// Type returns the type the user wanted to register.
+func (e ContainerSealedError) Type() reflect.Type {
+	return e.ty
+}
+
+// Name returns the namespace the user wanted to register the type in.
+func (e ContainerSealedError) Name() string {
+	return e.name
+}
+
+func (e ContainerSealedError) Error() string {
+	var b strings.Builder
+	b.WriteString("cannot register type ")
+	b.WriteString(e.ty.String())
+	if e.name == "" {
+		b.WriteString(" in global namespace")
+	} else {
+		b.WriteString(" in namespace \"")
+		b.WriteString(e.name)
+		b.WriteString("\"")
+	}
+	b.WriteString(", because the container has been sealed")
+	return b.String()
+}
@@ -0,0 +1,209 @@
+package dino
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSealedContainerRejectsRegistrations(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[myInterface1, myStruct1](c))
+	assert.False(t, c.IsSealed())
+	assert.Nil(t, c.Seal())
+	assert.True(t, c.IsSealed())
+
+	err := Add[*myStruct1, myStruct1](c)
+	assert.ErrorAs(t, err, &ContainerSealedError{})
+	assert.Contains(t, err.Error(), "*dino.myStruct1 in global namespace")
+
+	err = AddInstanceNamed[*myStruct1](c, "foo", &myStruct1{})
+	assert.ErrorAs(t, err, &ContainerSealedError{})
+	assert.Contains(t, err.Error(), "\"foo\"")
+
+	assert.ErrorAs(t, AddAs[myStruct3](c, As[myInterface2]()), &ContainerSealedError{})
+	assert.ErrorAs(t, Alias[myInterface2, *myStruct3](c), &ContainerSealedError{})
+	assert.ErrorAs(t, AddIf(c, Profile("dev"), func(c *Container) error {
+		return Add[myInterface2, myStruct3](c)
+	}), &ContainerSealedError{})
+
+	_, err = Get[*myStruct1](c)
+	assert.ErrorAs(t, err, &BindingMissingError{})
+	_, err = Get[myInterface2](c)
+	assert.ErrorAs(t, err, &BindingMissingError{})
+}
+
+func TestRegistrationsRacingSealAreNotLost(t *testing.T) {
+	c := &Container{}
+	errs := make([]error, 10)
+	done := make(chan struct{})
+	var once sync.Once
+
+	// Register services while Seal is in the middle of evaluating conditions
+	assert.Nil(t, AddIf(c, When("registering", func() bool {
+		once.Do(func() {
+			go func() {
+				defer close(done)
+				for i := range errs {
+					errs[i] = AddInstanceNamed[*myStruct1](c, strconv.Itoa(i), &myStruct1{})
+				}
+			}()
+			select {
+			case <-done:
+			case <-time.After(100 * time.Millisecond):
+			}
+		})
+		return true
+	}), func(c *Container) error {
+		return Add[myInterface1, myStruct1](c)
+	}))
+
+	assert.Nil(t, c.Seal())
+	<-done
+
+	// Each registration has either made it into the snapshot or been rejected
+	for i, err := range errs {
+		if err == nil {
+			_, err = GetNamed[*myStruct1](c, strconv.Itoa(i))
+			assert.Nil(t, err)
+		} else {
+			assert.ErrorAs(t, err, &ContainerSealedError{})
+		}
+	}
+}
+
+func TestSealAllowsPredicatesToRegister(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AddIf(c, When("registering", func() bool {
+		return AddInstanceNamed[*myStruct1](c, "predicate", &myStruct1{}) == nil
+	}), func(c *Container) error {
+		return Add[myInterface1, myStruct1](c)
+	}))
+
+	finishesIn(t, time.Second, func() {
+		assert.Nil(t, c.Seal())
+	})
+	assert.True(t, c.IsSealed())
+
+	_, err := GetNamed[*myStruct1](c, "predicate")
+	assert.Nil(t, err)
+	_, err = Get[myInterface1](c)
+	assert.Nil(t, err)
+}
+
+func TestSealedContainerResolves(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[myInterface1, myStruct1](c))
+	assert.Nil(t, AddTransientNamed[*myStruct1, myStruct1](c, "transient"))
+	assert.Nil(t, c.Seal())
+	assert.Nil(t, c.Seal())
+
+	s1, err := Get[myInterface1](c)
+	assert.Nil(t, err)
+	s2, err := Get[myInterface1](c)
+	assert.Nil(t, err)
+	assert.Same(t, s1, s2)
+
+	t1, err := GetNamed[*myStruct1](c, "transient")
+	assert.Nil(t, err)
+	t2, err := GetNamed[*myStruct1](c, "transient")
+	assert.Nil(t, err)
+	assert.NotSame(t, t1, t2)
+}
+
+func TestSealEvaluatesConditionsOnce(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AddIf(c, Profile("dev"), func(c *Container) error {
+		return Add[mailSender, fakeMailSender](c)
+	}))
+	assert.Nil(t, AddIf(c, Not(Profile("dev")), func(c *Container) error {
+		return Add[mailSender, realMailSender](c)
+	}))
+
+	t.Setenv(ProfileEnv, "dev")
+	assert.Nil(t, c.Seal())
+
+	t.Setenv(ProfileEnv, "prod")
+	sender, err := Get[mailSender](c)
+	assert.Nil(t, err)
+	assert.Equal(t, "fake:me", sender.Send("me"))
+
+	infos := c.Bindings()
+	assert.Len(t, infos, 1)
+	assert.Contains(t, infos[0].Details, "if profile dev (active)")
+	assert.Contains(t, infos[0].Details, "if not (profile dev) (inactive)")
+}
+
+func TestSealCallsPredicatesOnce(t *testing.T) {
+	calls := make(map[string]int)
+	predicate := func(name string, result bool) Condition {
+		return When(name, func() bool {
+			calls[name]++
+			return result
+		})
+	}
+
+	c := &Container{}
+	assert.Nil(t, AddIf(c, predicate("fake", true), func(c *Container) error {
+		return AddIf(c, predicate("nested", false), func(c *Container) error {
+			return Add[mailSender, fakeMailSender](c)
+		})
+	}))
+	assert.Nil(t, AddIf(c, predicate("real", true), func(c *Container) error {
+		return Add[mailSender, realMailSender](c)
+	}))
+	assert.Nil(t, c.Seal())
+
+	assert.Equal(t, map[string]int{"fake": 1, "nested": 1, "real": 1}, calls)
+	assert.Equal(t, "real:me", MustGet[mailSender](c).Send("me"))
+	assert.Contains(t, c.Bindings()[0].Details, "if nested (inactive)")
+	assert.Equal(t, map[string]int{"fake": 1, "nested": 1, "real": 1}, calls)
+}
+
+func TestSealFailsMissingAliasTarget(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AliasNamed[*myStruct1, *myStruct1](c, "primary", "accounts"))
+
+	err := c.Seal()
+	assert.ErrorAs(t, err, &BindingMissingError{})
+	assert.Contains(t, err.Error(), "accounts")
+	assert.False(t, c.IsSealed())
+}
+
+func TestSealFailsCyclicDependency(t *testing.T) {
+	type (
+		Dep interface{}
+		X   struct {
+			Dep Dep
+		}
+		Y struct {
+			X *X
+		}
+	)
+
+	c := &Container{}
+	assert.Nil(t, AddTransient[*X, X](c))
+	assert.Nil(t, Add[*Y, Y](c))
+	assert.Nil(t, Alias[Dep, *Y](c))
+
+	err := c.Seal()
+	assert.ErrorAs(t, err, &CyclicDependencyError{})
+	assert.False(t, c.IsSealed())
+}
+
+func TestSealAllowsSingletonCycles(t *testing.T) {
+	type X struct {
+		Self *X
+	}
+
+	c := &Container{}
+	assert.Nil(t, Add[*X, X](c))
+	assert.Nil(t, c.Seal())
+
+	x, err := Get[*X](c)
+	assert.Nil(t, err)
+	assert.Same(t, x, x.Self)
+}
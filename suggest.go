@@ -0,0 +1,157 @@
+package dino
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maxSuggestions limits how many near matches a BindingMissingError describes.
+const maxSuggestions = 3
+
+// suggestion describes a registered binding the user might have meant.
+type suggestion struct {
+	text  string
+	score int // Lower is better.
+}
+
+// suggest looks for registered bindings similar to a missing one, eg. registered under a different name
+// or as a pointer instead of a value, and describes them, best matches first.
+func (c *Container) suggest(ty reflect.Type, name string) []string {
+	if c == nil || ty == nil {
+		return nil
+	}
+
+	var found []suggestion
+	seen := make(map[string]bool)
+	for owner := c; owner != nil; owner = owner.parent {
+		found = owner.collectSuggestions(ty, name, seen, found)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].score != found[j].score {
+			return found[i].score < found[j].score
+		}
+		return found[i].text < found[j].text
+	})
+
+	if len(found) > maxSuggestions {
+		found = found[:maxSuggestions]
+	}
+	texts := make([]string, len(found))
+	for i, s := range found {
+		texts[i] = s.text
+	}
+	return texts
+}
+
+// collectSuggestions appends suggestions found among the bindings registered in the container.
+// Seen contains keys that have already been suggested, or overridden by a child.
+func (c *Container) collectSuggestions(ty reflect.Type, name string, seen map[string]bool, found []suggestion) []suggestion {
+	c.m.Range(func(key, value any) bool {
+		other := key.(reflect.Type)
+		typeScore, reason, ok := typeSimilarity(ty, other)
+		if !ok {
+			return true
+		}
+
+		value.(*sync.Map).Range(func(key, value any) bool {
+			otherName := key.(string)
+			if _, ok := value.(Binding); !ok || (other == ty && otherName == name) {
+				return true
+			}
+
+			// Registering the same type under a different name is a typical mistake,
+			// so those are always suggested, but other types need to have a similar name
+			nameScore := editDistance(name, otherName)
+			if other != ty && nameScore > maxNameDistance(name) {
+				return true
+			}
+
+			text := formatKey(other, otherName) + reason
+			if seen[text] {
+				return true
+			}
+			seen[text] = true
+
+			found = append(found, suggestion{text: text, score: typeScore + nameScore})
+			return true
+		})
+		return true
+	})
+
+	return found
+}
+
+// typeSimilarity checks whether a type could have been meant instead of a requested one.
+// The lower the score, the more similar the types are.
+func typeSimilarity(requested, other reflect.Type) (score int, reason string, ok bool) {
+	switch {
+	case other == requested:
+		return 0, "", true
+	case other == reflect.PointerTo(requested),
+		requested.Kind() == reflect.Pointer && requested.Elem() == other:
+		return 1, "", true
+	case requested.Kind() == reflect.Interface && other.Implements(requested):
+		return 2, " [implements " + requested.String() + "]", true
+	case editDistance(requested.String(), other.String()) <= 2:
+		return 3, "", true
+	default:
+		return 0, "", false
+	}
+}
+
+// maxNameDistance returns how many edits can make a name still count as similarly spelled.
+func maxNameDistance(name string) int {
+	if d := len(name) / 3; d > 2 {
+		return d
+	}
+	return 2
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// formatSuggestions describes suggestions as a suffix of an error message.
+func formatSuggestions(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return "; did you mean " + strings.Join(suggestions, " or ") + "?"
+}
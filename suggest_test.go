@@ -0,0 +1,103 @@
+package dino
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func missingSuggestions(t *testing.T, err error) []string {
+	var missing BindingMissingError
+	assert.True(t, errors.As(err, &missing))
+	return missing.Suggestions()
+}
+
+func TestMissingBindingSuggestsSimilarNames(t *testing.T) {
+	type db struct{}
+	c := &Container{}
+	assert.Nil(t, AddNamed[*db, db](c, "accounts"))
+	assert.Nil(t, AddNamed[*db, db](c, "payments"))
+
+	_, err := GetNamed[*db](c, "acounts")
+	assert.Equal(t, []string{"*dino.db (named:accounts)", "*dino.db (named:payments)"}, missingSuggestions(t, err))
+	assert.EqualError(t, err, `container did not have any info about type *dino.db in namespace "acounts"; `+
+		`did you mean *dino.db (named:accounts) or *dino.db (named:payments)?`)
+}
+
+func TestMissingBindingSuggestsPointerMixUps(t *testing.T) {
+	type db struct{}
+	c := &Container{}
+	assert.Nil(t, Add[*db, db](c))
+
+	_, err := Get[db](c)
+	assert.Equal(t, []string{"*dino.db"}, missingSuggestions(t, err))
+
+	c = &Container{}
+	assert.Nil(t, Add[db, db](c))
+
+	_, err = Get[*db](c)
+	assert.Equal(t, []string{"dino.db"}, missingSuggestions(t, err))
+}
+
+func TestMissingBindingSuggestsImplementations(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, Add[*realMailSender, realMailSender](c))
+	assert.Nil(t, AddNamed[*fakeMailSender, fakeMailSender](c, "unrelated"))
+
+	_, err := Get[mailSender](c)
+	assert.Equal(t, []string{"*dino.realMailSender [implements dino.mailSender]"}, missingSuggestions(t, err))
+}
+
+func TestMissingBindingWithoutSimilarBindings(t *testing.T) {
+	type db struct{}
+	type cache struct{}
+	c := &Container{}
+	assert.Nil(t, AddNamed[*cache, cache](c, "accounts"))
+
+	_, err := GetNamed[*db](c, "accounts")
+	assert.Empty(t, missingSuggestions(t, err))
+	assert.EqualError(t, err, `container did not have any info about type *dino.db in namespace "accounts"`)
+}
+
+func TestMissingBindingSuggestionsDoNotChangeLater(t *testing.T) {
+	type db struct{}
+	c := &Container{}
+	assert.Nil(t, AddNamed[*db, db](c, "accounts"))
+
+	_, err := GetNamed[*db](c, "acounts")
+	message := err.Error()
+	assert.Nil(t, AddNamed[*db, db](c, "acount"))
+
+	assert.Equal(t, []string{"*dino.db (named:accounts)"}, missingSuggestions(t, err))
+	assert.Equal(t, message, err.Error())
+}
+
+func TestMissingBindingSuggestionsAreLimited(t *testing.T) {
+	type db struct{}
+	c := &Container{}
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		assert.Nil(t, AddNamed[*db, db](c, name))
+	}
+
+	_, err := GetNamed[*db](c, "x")
+	assert.Len(t, missingSuggestions(t, err), maxSuggestions)
+}
+
+func TestSealingSuggestsAliasTargets(t *testing.T) {
+	c := &Container{}
+	assert.Nil(t, AddNamed[*realMailSender, realMailSender](c, "smtp"))
+	assert.Nil(t, AliasNamed[mailSender, *realMailSender](c, "", "smpt"))
+
+	err := c.Seal()
+	assert.ErrorIs(t, err, ErrBindingMissing)
+	assert.Contains(t, err.Error(), "did you mean *dino.realMailSender (named:smtp)?")
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("", ""))
+	assert.Equal(t, 3, editDistance("", "abc"))
+	assert.Equal(t, 1, editDistance("accounts", "acounts"))
+	assert.Equal(t, 2, editDistance("smtp", "smpt"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+}
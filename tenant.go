@@ -0,0 +1,283 @@
+package dino
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// TenantID is registered in each tenant scope created by a TenantContainer,
+// so that services can find out which tenant they belong to.
+// Like other values, it only gets injected into fields with a dino tag, eg. `dino:""`.
+type TenantID string
+
+// TenantSetup registers services specific to a tenant in its scope.
+//
+// It gets called without locking the TenantContainer, so scopes of other tenants stay available meanwhile.
+// Requests for the tenant being set up wait for it to finish, so setup must not request its own scope.
+type TenantSetup func(tenantID string, c *Container) error
+
+// EvictionPolicy decides when a TenantContainer disposes of tenant scopes.
+type EvictionPolicy struct {
+	maxTenants  int
+	idleTimeout time.Duration
+}
+
+// EvictLeastRecentlyUsed keeps at most n tenant scopes,
+// disposing of the least recently used ones when a new one is needed.
+func EvictLeastRecentlyUsed(n int) EvictionPolicy {
+	return EvictionPolicy{maxTenants: n}
+}
+
+// EvictIdle disposes of tenant scopes that have not been used for a provided duration.
+func EvictIdle(timeout time.Duration) EvictionPolicy {
+	return EvictionPolicy{idleTimeout: timeout}
+}
+
+// tenantScope is a container of a single tenant.
+type tenantScope struct {
+	id       string
+	c        *Container
+	lastUsed time.Time
+}
+
+// pendingScope is a scope being set up, which other requests for the same tenant wait for.
+type pendingScope struct {
+	done chan struct{} // Closed once the scope has been set up.
+	c    *Container
+	err  error
+}
+
+// TenantContainer manages child containers (scopes) of a root container, one for each tenant.
+//
+// Scopes get created on first use. Services registered in them by the setup function,
+// eg. database pools or caches, are specific to their tenants,
+// while all the other services come from the root container and are shared.
+// Each scope also has the ID of its tenant registered as TenantID.
+//
+// When a scope gets evicted, its singletons get closed, as described by Container.Close.
+// Services that are still being used at that time are closed anyway,
+// so the eviction policy should leave enough time for them to be released.
+type TenantContainer struct {
+	root    *Container
+	setup   TenantSetup
+	policy  EvictionPolicy
+	now     func() time.Time // Replaced in tests.
+	mu      sync.Mutex
+	scopes  map[string]*list.Element // Elements of the order list, by tenant ID.
+	pending map[string]*pendingScope // Scopes being set up, by tenant ID.
+	order   *list.List               // Scopes, from the most recently used one.
+	stop    chan struct{}            // Closed to stop evicting idle scopes in the background.
+	stopped sync.Once
+}
+
+// NewTenantContainer creates a container of tenant scopes on top of a root container.
+// Setup can be nil, if the scopes do not need any services of their own.
+//
+// Policies can be combined, eg. to limit the number of scopes and dispose of idle ones at the same time.
+// If there is an idle timeout, idle scopes get evicted in the background, until the container is closed.
+func NewTenantContainer(root *Container, setup TenantSetup, policies ...EvictionPolicy) *TenantContainer {
+	tc := &TenantContainer{
+		root:    root,
+		setup:   setup,
+		now:     time.Now,
+		scopes:  make(map[string]*list.Element),
+		pending: make(map[string]*pendingScope),
+		order:   list.New(),
+		stop:    make(chan struct{}),
+	}
+
+	for _, p := range policies {
+		if p.maxTenants > 0 {
+			tc.policy.maxTenants = p.maxTenants
+		}
+		if p.idleTimeout > 0 {
+			tc.policy.idleTimeout = p.idleTimeout
+		}
+	}
+
+	if tc.policy.idleTimeout > 0 {
+		go tc.evictIdleEvery(tc.policy.idleTimeout / 2)
+	}
+
+	return tc
+}
+
+// Root returns the container shared by all the tenants.
+func (tc *TenantContainer) Root() *Container {
+	return tc.root
+}
+
+// Scope returns the container of a tenant, creating it if necessary.
+//
+// Concurrent requests for a tenant without a scope share a single setup.
+// If it fails, they all get the error, and the next request tries again.
+func (tc *TenantContainer) Scope(tenantID string) (*Container, error) {
+	tc.mu.Lock()
+
+	if e, ok := tc.scopes[tenantID]; ok {
+		s := e.Value.(*tenantScope)
+		s.lastUsed = tc.now()
+		tc.order.MoveToFront(e)
+		tc.mu.Unlock()
+		return s.c, nil
+	}
+
+	if p, ok := tc.pending[tenantID]; ok {
+		tc.mu.Unlock()
+		<-p.done
+		return p.c, p.err
+	}
+
+	p := &pendingScope{done: make(chan struct{})}
+	tc.pending[tenantID] = p
+	tc.mu.Unlock()
+
+	// Setup might take a while, eg. to connect to a database, so other tenants must not wait for it
+	p.c, p.err = tc.newScope(tenantID)
+
+	tc.mu.Lock()
+	delete(tc.pending, tenantID)
+	var evicted []*tenantScope
+	if p.err == nil {
+		tc.scopes[tenantID] = tc.order.PushFront(&tenantScope{id: tenantID, c: p.c, lastUsed: tc.now()})
+		for tc.policy.maxTenants > 0 && tc.order.Len() > tc.policy.maxTenants {
+			evicted = append(evicted, tc.remove(tc.order.Back()))
+		}
+	}
+	tc.mu.Unlock()
+	close(p.done)
+
+	// Evicted scopes get closed without holding the lock, so that other tenants do not have to wait.
+	// Their errors are not related to the requested tenant, so they do not get reported.
+	_ = closeScopes(evicted)
+	return p.c, p.err
+}
+
+// newScope creates the container of a tenant and registers its services.
+func (tc *TenantContainer) newScope(tenantID string) (*Container, error) {
+	c := tc.root.NewChild()
+	if err := AddInstance[TenantID](c, TenantID(tenantID)); err != nil {
+		return nil, err
+	}
+	if tc.setup != nil {
+		if err := tc.setup(tenantID, c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Evict disposes of the scope of a tenant, if it exists.
+// The next request for the tenant creates a new one.
+func (tc *TenantContainer) Evict(tenantID string) error {
+	tc.mu.Lock()
+	e, ok := tc.scopes[tenantID]
+	if !ok {
+		tc.mu.Unlock()
+		return nil
+	}
+	s := tc.remove(e)
+	tc.mu.Unlock()
+
+	return s.c.Close()
+}
+
+// EvictIdle disposes of scopes that have been idle for longer than allowed by the eviction policy.
+// It gets called periodically in the background, so calling it manually is only needed in tests.
+func (tc *TenantContainer) EvictIdle() error {
+	if tc.policy.idleTimeout <= 0 {
+		return nil
+	}
+
+	tc.mu.Lock()
+	var evicted []*tenantScope
+	deadline := tc.now().Add(-tc.policy.idleTimeout)
+	for e := tc.order.Back(); e != nil && !e.Value.(*tenantScope).lastUsed.After(deadline); e = tc.order.Back() {
+		evicted = append(evicted, tc.remove(e))
+	}
+	tc.mu.Unlock()
+
+	return closeScopes(evicted)
+}
+
+// Tenants returns IDs of tenants that have scopes, from the most recently used one.
+func (tc *TenantContainer) Tenants() []string {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	ids := make([]string, 0, tc.order.Len())
+	for e := tc.order.Front(); e != nil; e = e.Next() {
+		ids = append(ids, e.Value.(*tenantScope).id)
+	}
+	return ids
+}
+
+// Close disposes of all the tenant scopes and stops evicting idle ones in the background.
+// The root container is left as it is.
+//
+// All the scopes get closed, even if some of them fail, and the first error is returned.
+func (tc *TenantContainer) Close() error {
+	tc.stopped.Do(func() {
+		close(tc.stop)
+	})
+
+	tc.mu.Lock()
+	var evicted []*tenantScope
+	for e := tc.order.Back(); e != nil; e = tc.order.Back() {
+		evicted = append(evicted, tc.remove(e))
+	}
+	tc.mu.Unlock()
+
+	return closeScopes(evicted)
+}
+
+// remove forgets a scope. It must be called with the lock held.
+func (tc *TenantContainer) remove(e *list.Element) *tenantScope {
+	s := tc.order.Remove(e).(*tenantScope)
+	delete(tc.scopes, s.id)
+	return s
+}
+
+// evictIdleEvery evicts idle scopes periodically, until the container gets closed.
+func (tc *TenantContainer) evictIdleEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// There is no one to report errors to, but the scopes get closed regardless
+			_ = tc.EvictIdle()
+		case <-tc.stop:
+			return
+		}
+	}
+}
+
+// closeScopes closes containers of evicted scopes and returns the first error.
+func closeScopes(scopes []*tenantScope) error {
+	var firstErr error
+	for _, s := range scopes {
+		if err := s.c.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// GetForTenant tries to create, retrieve or inject an object of type T
+// from the scope of a tenant, creating the scope if necessary.
+func GetForTenant[T any](tc *TenantContainer, tenantID string) (svc T, err error) {
+	return GetNamedForTenant[T](tc, tenantID, "")
+}
+
+// GetNamedForTenant tries to create, retrieve or inject an object of type T
+// from the scope of a tenant, creating the scope if necessary.
+func GetNamedForTenant[T any](tc *TenantContainer, tenantID string, name string) (svc T, err error) {
+	c, err := tc.Scope(tenantID)
+	if err != nil {
+		return
+	}
+	return GetNamed[T](c, name)
+}
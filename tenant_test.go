@@ -0,0 +1,191 @@
+package dino
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tenantDB struct {
+	Tenant TenantID `dino:""`
+	closed bool
+}
+
+func (db *tenantDB) Close() error {
+	db.closed = true
+	return nil
+}
+
+type tenantService struct {
+	DB    *tenantDB
+	Clock clock
+}
+
+func newTestTenantContainer(policies ...EvictionPolicy) *TenantContainer {
+	root := &Container{}
+	MustAdd[clock, realClock](root)
+	MustAddTransient[*tenantService, tenantService](root)
+
+	return NewTenantContainer(root, func(tenantID string, c *Container) error {
+		return Add[*tenantDB, tenantDB](c)
+	}, policies...)
+}
+
+func TestTenantsGetTheirOwnScopes(t *testing.T) {
+	tc := newTestTenantContainer()
+	defer tc.Close()
+
+	acme, err := GetForTenant[*tenantService](tc, "acme")
+	assert.Nil(t, err)
+	assert.Equal(t, TenantID("acme"), acme.DB.Tenant)
+	assert.Equal(t, 1, acme.Clock.Now())
+
+	globex := MustGetForTenant[*tenantService](tc, "globex")
+	assert.Equal(t, TenantID("globex"), globex.DB.Tenant)
+	assert.NotSame(t, acme.DB, globex.DB)
+	assert.Same(t, acme.DB, MustGetForTenant[*tenantDB](tc, "acme"))
+	assert.Equal(t, MustGet[clock](tc.Root()), globex.Clock)
+
+	assert.Equal(t, []string{"acme", "globex"}, tc.Tenants())
+}
+
+func TestTenantSetupErrorsAreReturned(t *testing.T) {
+	tc := NewTenantContainer(&Container{}, func(tenantID string, c *Container) error {
+		return assert.AnError
+	})
+	defer tc.Close()
+
+	_, err := GetForTenant[TenantID](tc, "acme")
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Empty(t, tc.Tenants())
+}
+
+func TestTenantSetupDoesNotBlockOtherTenants(t *testing.T) {
+	started, release := make(chan struct{}, 2), make(chan struct{})
+	var calls int32
+	tc := NewTenantContainer(&Container{}, func(tenantID string, c *Container) error {
+		if tenantID == "slow" {
+			atomic.AddInt32(&calls, 1)
+			started <- struct{}{}
+			<-release
+		}
+		return nil
+	})
+	defer tc.Close()
+	var released sync.Once
+	releaseSlow := func() { released.Do(func() { close(release) }) }
+	defer releaseSlow()
+
+	scopes := make(chan *Container, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			c, _ := tc.Scope("slow")
+			scopes <- c
+		}()
+	}
+	<-started
+
+	finishesIn(t, time.Second, func() {
+		assert.Equal(t, TenantID("fast"), MustGetForTenant[TenantID](tc, "fast"))
+	})
+	assert.Equal(t, []string{"fast"}, tc.Tenants())
+
+	releaseSlow()
+	first, second := <-scopes, <-scopes
+	assert.NotNil(t, first)
+	assert.Same(t, first, second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestTenantSetupIsRetriedAfterError(t *testing.T) {
+	fail := true
+	tc := NewTenantContainer(&Container{}, func(tenantID string, c *Container) error {
+		if fail {
+			return assert.AnError
+		}
+		return nil
+	})
+	defer tc.Close()
+
+	_, err := tc.Scope("acme")
+	assert.ErrorIs(t, err, assert.AnError)
+
+	fail = false
+	c, err := tc.Scope("acme")
+	assert.Nil(t, err)
+	assert.NotNil(t, c)
+	assert.Equal(t, []string{"acme"}, tc.Tenants())
+}
+
+func TestTenantsGetEvictedWhenLeastRecentlyUsed(t *testing.T) {
+	tc := newTestTenantContainer(EvictLeastRecentlyUsed(2))
+	defer tc.Close()
+
+	acme := MustGetForTenant[*tenantDB](tc, "acme")
+	globex := MustGetForTenant[*tenantDB](tc, "globex")
+	MustGetForTenant[*tenantDB](tc, "acme")
+	MustGetForTenant[*tenantDB](tc, "initech")
+
+	assert.True(t, globex.closed)
+	assert.False(t, acme.closed)
+	assert.Equal(t, []string{"initech", "acme"}, tc.Tenants())
+
+	// Evicted tenants get new scopes
+	assert.NotSame(t, globex, MustGetForTenant[*tenantDB](tc, "globex"))
+}
+
+func TestIdleTenantsGetEvicted(t *testing.T) {
+	now := time.Unix(0, 0)
+	tc := newTestTenantContainer(EvictIdle(time.Hour))
+	tc.now = func() time.Time { return now }
+	defer tc.Close()
+
+	acme := MustGetForTenant[*tenantDB](tc, "acme")
+	now = now.Add(40 * time.Minute)
+	globex := MustGetForTenant[*tenantDB](tc, "globex")
+	now = now.Add(30 * time.Minute)
+
+	assert.Nil(t, tc.EvictIdle())
+	assert.True(t, acme.closed)
+	assert.False(t, globex.closed)
+	assert.Equal(t, []string{"globex"}, tc.Tenants())
+}
+
+func TestTenantsGetEvictedManually(t *testing.T) {
+	tc := newTestTenantContainer()
+
+	acme := MustGetForTenant[*tenantDB](tc, "acme")
+	assert.Nil(t, tc.Evict("acme"))
+	assert.Nil(t, tc.Evict("unknown"))
+	assert.True(t, acme.closed)
+
+	globex := MustGetForTenant[*tenantDB](tc, "globex")
+	assert.Nil(t, tc.Close())
+	assert.True(t, globex.closed)
+	assert.Empty(t, tc.Tenants())
+}
+
+func TestMustGetForTenantPanics(t *testing.T) {
+	tc := NewTenantContainer(&Container{}, nil)
+	defer tc.Close()
+
+	assert.Equal(t, TenantID("acme"), MustGetForTenant[TenantID](tc, "acme"))
+	assert.Panics(t, func() { MustGetNamedForTenant[*tenantDB](tc, "acme", "main") })
+
+	_, err := GetForTenant[*tenantDB](tc, "acme")
+	assert.True(t, errors.Is(err, ErrBindingMissing))
+}
+
+func TestIdleTenantsGetEvictedInBackground(t *testing.T) {
+	tc := newTestTenantContainer(EvictIdle(10 * time.Millisecond))
+	defer tc.Close()
+
+	MustGetForTenant[*tenantDB](tc, "acme")
+	assert.Eventually(t, func() bool {
+		return len(tc.Tenants()) == 0
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,62 @@
+// Command dino generates reflection-free wiring code from Dino module definitions.
+//
+// It is meant to be run with go:generate, next to the module function:
+//
+//	//go:generate go run github.com/frixuu/dino/tools/cmd/dino -module Register
+//	func Register(c *dino.Container) error {
+//		...
+//	}
+//
+// This generates a RegisterGenerated function, which registers the same services,
+// but constructs them without reflection.
+//
+// For go run to find the command, the module has to depend on it, eg. through a file
+// excluded from builds with a tools build tag, which imports it:
+//
+//	import _ "github.com/frixuu/dino/tools/cmd/dino"
+//
+// It can also be installed with go install github.com/frixuu/dino/tools/cmd/dino@latest
+// and run as "dino" instead.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/frixuu/dino/tools/gen"
+)
+
+func main() {
+	module := flag.String("module", "Register", "name of the module function")
+	fn := flag.String("func", "", "name of the generated function (default: module name + \"Generated\")")
+	out := flag.String("out", "", "output file (default: <module>_dino.go, in lowercase)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: dino [flags] [package directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	path := *out
+	if path == "" {
+		path = filepath.Join(dir, strings.ToLower(*module)+"_dino.go")
+	}
+
+	src, err := gen.Generate(gen.Config{Dir: dir, Module: *module, Func: *fn, Out: path})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "dino:", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "dino:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,18 @@
+// Command dinolint reports common misuses of the Dino container without running the code.
+//
+// It can be run on its own or as a vet tool:
+//
+//	go run github.com/frixuu/dino/tools/cmd/dinolint@latest ./...
+//
+//	go install github.com/frixuu/dino/tools/cmd/dinolint@latest
+//	go vet -vettool=$(which dinolint) ./...
+package main
+
+import (
+	"github.com/frixuu/dino/tools/dinolint"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(dinolint.Analyzer)
+}
@@ -0,0 +1,384 @@
+// Package dinolint defines an analyzer that reports common misuses of the Dino container
+// without running the code.
+package dinolint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/frixuu/dino/tools/internal/dinotypes"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `report common misuses of the Dino container
+
+The dinolint analyzer reports:
+  - registrations the container would reject at runtime,
+    eg. Add[I, Impl] where *Impl does not implement I,
+  - dino struct tags with unknown keys,
+  - named: tags of injected fields, for which no service of that name is ever registered,
+  - Must* functions called outside of initialization (init, main and package-level variables).
+
+Names are only checked in main packages, where all the registrations of a program are known.
+If a program registers services under names computed at runtime, they are not checked at all.`
+
+// Analyzer reports common misuses of the Dino container.
+var Analyzer = &analysis.Analyzer{
+	Name:      "dinolint",
+	Doc:       doc,
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(namesFact)},
+}
+
+// nameArgs lists registration functions that take a name,
+// along with the index of the name argument.
+var nameArgs = map[string]int{
+	"AddNamed":                 1,
+	"AddTransientNamed":        1,
+	"AddInstanceNamed":         1,
+	"AddFactoryNamed":          1,
+	"AddTransientFactoryNamed": 1,
+	"AliasNamed":               1,
+	"ReplaceNamed":             1,
+	"AsNamed":                  0,
+}
+
+// namesFact records which names a package registers services under
+// and which names the fields of services it registers get injected from.
+type namesFact struct {
+	Registered []string     // Names registered by the package.
+	Dynamic    bool         // Whether the package also registers names computed at runtime.
+	Wanted     []wantedName // Names wanted by fields of the services registered by the package.
+}
+
+// wantedName describes a field that gets injected from a named service.
+type wantedName struct {
+	Name  string // Name the field gets injected from.
+	Field string // Description of the field, eg. *app.Controller.DB.
+	Site  string // Position of the registration, in a human-readable form.
+
+	pos token.Pos // Position of the registration, only valid in the package that made it.
+}
+
+func (*namesFact) AFact() {}
+
+func (f *namesFact) String() string {
+	return "registers(" + strings.Join(f.Registered, ", ") + ")"
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	fact := &namesFact{}
+	registered := make(map[string]bool)
+	wanted := make(map[string]bool)
+
+	filter := []ast.Node{(*ast.StructType)(nil), (*ast.CallExpr)(nil)}
+	inspect.WithStack(filter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		switch n := n.(type) {
+		case *ast.StructType:
+			checkTags(pass, n)
+		case *ast.CallExpr:
+			fn, typeArgs := dinotypes.CalledFunc(pass.TypesInfo, n)
+			if fn == nil {
+				return true
+			}
+
+			name := fn.Name()
+			if strings.HasPrefix(name, "Must") {
+				if !isInitPath(pass, stack) {
+					pass.Reportf(n.Pos(), "dino.%s panics on error and should only be called during initialization, "+
+						"use dino.%s and handle the error instead", name, strings.TrimPrefix(name, "Must"))
+				}
+				name = strings.TrimPrefix(name, "Must")
+			}
+
+			if i, ok := nameArgs[name]; ok && i < len(n.Args) {
+				if value, ok := constantString(pass, n.Args[i]); !ok {
+					fact.Dynamic = true
+				} else if !registered[value] {
+					registered[value] = true
+					fact.Registered = append(fact.Registered, value)
+				}
+			}
+
+			// Bundles register their fields under the names from their tags
+			if name == "AddBundle" && len(n.Args) == 2 {
+				if names, ok := bundleNames(pass, n.Args[1]); !ok {
+					fact.Dynamic = true
+				} else {
+					for _, value := range names {
+						if !registered[value] {
+							registered[value] = true
+							fact.Registered = append(fact.Registered, value)
+						}
+					}
+				}
+			}
+
+			impl := checkRegistration(pass, n, name, typeArgs)
+			if impl == nil {
+				return true
+			}
+
+			for _, f := range dinotypes.InjectableFields(impl) {
+				want := wantedName{
+					Name:  f.Name,
+					Field: dinotypes.TypeString(types.NewPointer(impl)) + "." + f.Var.Name(),
+					Site:  pass.Fset.Position(n.Pos()).String(),
+					pos:   n.Pos(),
+				}
+				if want.Name != "" && !wanted[want.Field] {
+					wanted[want.Field] = true
+					fact.Wanted = append(fact.Wanted, want)
+				}
+			}
+		}
+
+		return true
+	})
+
+	// Dino itself passes names around in variables, which says nothing about the program
+	if pass.Pkg.Path() == dinotypes.Path {
+		return nil, nil
+	}
+
+	sort.Strings(fact.Registered)
+	if len(fact.Registered) > 0 || fact.Dynamic || len(fact.Wanted) > 0 {
+		pass.ExportPackageFact(fact)
+	}
+
+	if pass.Pkg.Name() == "main" {
+		checkNames(pass, fact)
+	}
+
+	return nil, nil
+}
+
+// bundleNames returns the names a bundle passed to AddBundle registers its fields under.
+// If the type of the bundle is not known statically, it returns false.
+func bundleNames(pass *analysis.Pass, arg ast.Expr) ([]string, bool) {
+	ptr, ok := pass.TypesInfo.TypeOf(arg).Underlying().(*types.Pointer)
+	if !ok {
+		return nil, false
+	}
+	if _, ok := ptr.Elem().Underlying().(*types.Struct); !ok {
+		return nil, false
+	}
+
+	var names []string
+	for _, f := range dinotypes.InjectableFields(ptr.Elem()) {
+		names = append(names, f.Name)
+	}
+	return names, true
+}
+
+// checkRegistration reports registrations the container would reject at runtime.
+//
+// If the registration makes the container construct a struct,
+// the type of the struct is returned, so that its fields can be checked.
+func checkRegistration(pass *analysis.Pass, call *ast.CallExpr, name string, typeArgs []types.Type) types.Type {
+	for _, t := range typeArgs {
+		if hasTypeParams(t) {
+			// Cannot be checked without knowing the actual types
+			return nil
+		}
+	}
+
+	switch name {
+	case "Add", "AddNamed", "AddTransient", "AddTransientNamed":
+		if err := dinotypes.CheckImplType(typeArgs[0], typeArgs[1]); err != nil {
+			pass.Reportf(call.Pos(), "%v", err)
+			return nil
+		}
+		return typeArgs[1]
+
+	case "AddAs":
+		impl := typeArgs[0]
+		if err := dinotypes.CheckImplType(types.NewPointer(impl), impl); err != nil {
+			pass.Reportf(call.Pos(), "%v", err)
+			return nil
+		}
+
+		ok := true
+		for _, arg := range call.Args[1:] {
+			opt, isCall := ast.Unparen(arg).(*ast.CallExpr)
+			if !isCall {
+				continue
+			}
+
+			fn, optArgs := dinotypes.CalledFunc(pass.TypesInfo, opt)
+			if fn == nil || (fn.Name() != "As" && fn.Name() != "AsNamed") || hasTypeParams(optArgs[0]) {
+				continue
+			}
+
+			if err := dinotypes.CheckAsOption(optArgs[0], impl); err != nil {
+				pass.Reportf(opt.Pos(), "%v", err)
+				ok = false
+			}
+		}
+
+		if !ok {
+			return nil
+		}
+		return impl
+
+	case "Alias", "AliasNamed":
+		if err := dinotypes.CheckAlias(typeArgs[0], typeArgs[1]); err != nil {
+			pass.Reportf(call.Pos(), "%v", err)
+		}
+	}
+
+	return nil
+}
+
+// checkTags reports unknown keys in dino tags of struct fields.
+func checkTags(pass *analysis.Pass, st *ast.StructType) {
+	for _, field := range st.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+
+		opts, _ := dinotypes.ParseTag(tag)
+		for _, opt := range opts {
+			if opt.Key == "" && opt.Value == "" {
+				continue
+			}
+			if !dinotypes.TagKeys[opt.Key] {
+				pass.Reportf(field.Tag.Pos(), "unknown key %q in dino tag", opt.Key)
+			}
+		}
+	}
+}
+
+// checkNames reports fields wanting services under names that are never registered.
+//
+// It takes into account all the registrations made by a main package and its dependencies.
+func checkNames(pass *analysis.Pass, own *namesFact) {
+	registered := make(map[string]bool)
+	var wanted []wantedName
+	for _, pf := range pass.AllPackageFacts() {
+		fact, ok := pf.Fact.(*namesFact)
+		if !ok {
+			continue
+		}
+
+		if fact.Dynamic {
+			return
+		}
+		for _, name := range fact.Registered {
+			registered[name] = true
+		}
+		if pf.Package != pass.Pkg {
+			wanted = append(wanted, fact.Wanted...)
+		}
+	}
+
+	for _, want := range own.Wanted {
+		if !registered[want.Name] {
+			pass.Reportf(want.pos, "field %s wants a service named %q, which is never registered", want.Field, want.Name)
+		}
+	}
+
+	pos := mainPos(pass)
+	for _, want := range wanted {
+		if !registered[want.Name] {
+			pass.Reportf(pos, "field %s (registered at %s) wants a service named %q, which is never registered",
+				want.Field, want.Site, want.Name)
+		}
+	}
+}
+
+// mainPos returns the position of the main function,
+// or of the package clause, if there is no main function.
+func mainPos(pass *analysis.Pass) token.Pos {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "main" {
+				return fn.Name.Pos()
+			}
+		}
+	}
+	return pass.Files[0].Name.Pos()
+}
+
+// isInitPath checks whether the innermost node of the stack runs only during initialization,
+// that is in an init function, a main function, a package-level variable or a test.
+func isInitPath(pass *analysis.Pass, stack []ast.Node) bool {
+	if strings.HasSuffix(pass.Fset.File(stack[0].Pos()).Name(), "_test.go") {
+		return true
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncDecl:
+			if n.Recv != nil {
+				return false
+			}
+			return n.Name.Name == "init" || (n.Name.Name == "main" && pass.Pkg.Name() == "main")
+		case *ast.FuncLit:
+			// Function literals called right away run in the same context as their callers,
+			// unless they are started as goroutines
+			if parent, ok := stack[i-1].(*ast.CallExpr); ok && ast.Unparen(parent.Fun) == n {
+				if _, isGo := stack[i-2].(*ast.GoStmt); !isGo {
+					continue
+				}
+			}
+			return false
+		}
+	}
+
+	return true
+}
+
+// constantString returns the value of an expression, if it is a constant string.
+func constantString(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+// hasTypeParams checks whether a type refers to any type parameters.
+func hasTypeParams(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.TypeParam:
+		return true
+	case *types.Pointer:
+		return hasTypeParams(t.Elem())
+	case *types.Slice:
+		return hasTypeParams(t.Elem())
+	case *types.Array:
+		return hasTypeParams(t.Elem())
+	case *types.Chan:
+		return hasTypeParams(t.Elem())
+	case *types.Map:
+		return hasTypeParams(t.Key()) || hasTypeParams(t.Elem())
+	case *types.Named:
+		args := t.TypeArgs()
+		for i := 0; args != nil && i < args.Len(); i++ {
+			if hasTypeParams(args.At(i)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package main // want package:"registers\\(archive, audit\\)"
+
+import (
+	"example.com/lint/services"
+	"github.com/frixuu/dino"
+)
+
+type Storage struct {
+	dino.Out
+	Archive *services.DB `dino:"named:archive"`
+}
+
+type Archiver struct {
+	DB *services.DB `dino:"named:archive"`
+}
+
+type Handler struct {
+	Repo  *services.Repository
+	Cache services.Cache `dino:"named:hot"`
+}
+
+func main() { // want `field \*services.Repository.Replica \(registered at .*services.go:\d+:\d+\) wants a service named "replica", which is never registered`
+	c := &dino.Container{}
+	dino.MustAddInstanceNamed[*services.DB](c, "audit", &services.DB{})
+	if err := services.Register(c); err != nil {
+		panic(err)
+	}
+	if err := dino.AddBundle(c, &Storage{Archive: &services.DB{}}); err != nil {
+		panic(err)
+	}
+	if err := dino.Add[*Archiver, Archiver](c); err != nil {
+		panic(err)
+	}
+	if err := dino.Add[*Handler, Handler](c); err != nil { // want `field \*main.Handler.Cache wants a service named "hot", which is never registered`
+		panic(err)
+	}
+}
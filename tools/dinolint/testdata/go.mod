@@ -0,0 +1,7 @@
+module example.com/lint
+
+go 1.22
+
+require github.com/frixuu/dino v0.0.0
+
+replace github.com/frixuu/dino => ../../..
@@ -0,0 +1,68 @@
+package services // want package:"registers\\(primary\\)"
+
+import "github.com/frixuu/dino"
+
+type Cache interface {
+	Lookup(id int) string
+}
+
+type CacheImpl struct{}
+
+func (c *CacheImpl) Lookup(id int) string { return "" }
+
+type NotACache struct{}
+
+type DB struct{}
+
+type Repository struct {
+	Cache   Cache
+	Primary *DB `dino:"named:primary"`
+	Replica *DB `dino:"named:replica"`
+	Logs    *DB `dino:"name:logs"`        // want `unknown key "name" in dino tag`
+	Audit   *DB `dino:"named:audit;lazy"` // want `unknown key "lazy" in dino tag`
+	Backup  *DB `dino:"optional"`
+	private *DB `dino:"named:private"`
+}
+
+func Register(c *dino.Container) error {
+	if err := dino.Add[Cache, CacheImpl](c); err != nil {
+		return err
+	}
+	if err := dino.Add[Cache, NotACache](c); err != nil { // want `interface services.Cache is not implemented by type services.NotACache`
+		return err
+	}
+	if err := dino.AddTransient[*Repository, CacheImpl](c); err != nil { // want `service pointer type services.Repository does not match impl type services.CacheImpl`
+		return err
+	}
+	if err := dino.AddAs[CacheImpl](c, dino.As[Cache](), dino.As[CacheImpl]()); err != nil { // want `type services.CacheImpl is not a valid service type`
+		return err
+	}
+	if err := dino.Alias[Cache, *DB](c); err != nil { // want `interface services.Cache is not implemented by type \*services.DB`
+		return err
+	}
+	if err := dino.AddNamed[*DB, DB](c, "primary"); err != nil {
+		return err
+	}
+	return dino.Add[*Repository, Repository](c)
+}
+
+func Handle(c *dino.Container) string {
+	return dino.MustGet[Cache](c).Lookup(1) // want `dino.MustGet panics on error and should only be called during initialization, use dino.Get and handle the error instead`
+}
+
+func AddGeneric[T any](c *dino.Container) error {
+	return dino.Add[Cache, T](c)
+}
+
+var global = func() *dino.Container {
+	c := &dino.Container{}
+	dino.MustAdd[Cache, CacheImpl](c)
+	return c
+}()
+
+func init() {
+	dino.MustAdd[Cache, CacheImpl](global)
+	go func() {
+		dino.MustGet[Cache](global) // want `dino.MustGet panics on error`
+	}()
+}
@@ -0,0 +1,245 @@
+// Package gen generates reflection-free wiring code from Dino module definitions.
+//
+// A module is a function in the target package, which registers services
+// into a container passed to it, eg.:
+//
+//	func Register(c *dino.Container) error {
+//		dino.Add[AccountIdCache, AccountIdCacheImpl](c)
+//		dino.AddTransient[*AccountController, AccountController](c)
+//		return nil
+//	}
+//
+// The generated function registers the same services as the module,
+// but constructs them with plain Go code instead of injecting fields with reflection.
+// Services can still be retrieved with dino.Get, no matter which of the functions was used.
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/frixuu/dino/tools/internal/dinotypes"
+	"golang.org/x/tools/go/packages"
+)
+
+// Config describes what code should be generated.
+type Config struct {
+	Dir    string // Directory of the package containing the module.
+	Module string // Name of the module function.
+	Func   string // Name of the generated function. Defaults to Module + "Generated".
+	Out    string // Path of the generated file, which gets ignored while reading the package.
+}
+
+// Generate reads the module definition and returns formatted source code of the generated wiring.
+//
+// Registrations are checked the same way the runtime container checks them,
+// as well as for cycles and aliases pointing to missing bindings.
+func Generate(cfg Config) ([]byte, error) {
+	if cfg.Func == "" {
+		cfg.Func = cfg.Module + "Generated"
+	}
+
+	pkg, err := loadPackage(cfg.Dir, cfg.Out)
+	if err != nil {
+		return nil, err
+	}
+
+	decl := findModule(pkg, cfg.Module)
+	if decl == nil {
+		return nil, fmt.Errorf("module function %s not found in package %s", cfg.Module, pkg.PkgPath)
+	}
+
+	m, err := parseModule(pkg, decl)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+
+	src, err := render(pkg.Types, cfg, m)
+	if err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// loadPackage loads syntax and type information of a package in a provided directory.
+//
+// If the previously generated file exists, only its package clause is read,
+// so that it does not break loading the package once it gets out of date.
+func loadPackage(dir string, out string) (*packages.Package, error) {
+	mode := packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+		packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps
+	cfg := &packages.Config{Mode: mode, Dir: dir}
+
+	if out != "" {
+		if f, err := parser.ParseFile(token.NewFileSet(), out, nil, parser.PackageClauseOnly); err == nil {
+			abs, err := filepath.Abs(out)
+			if err != nil {
+				return nil, err
+			}
+			cfg.Overlay = map[string][]byte{abs: []byte("package " + f.Name.Name + "\n")}
+		}
+	}
+
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected a single package in %s, found %d", dir, len(pkgs))
+	}
+
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		msgs := make([]string, len(pkg.Errors))
+		for i, e := range pkg.Errors {
+			msgs[i] = e.Error()
+		}
+		return nil, errors.New(strings.Join(msgs, "\n"))
+	}
+
+	return pkg, nil
+}
+
+// findModule finds a top-level function declaration with a provided name.
+func findModule(pkg *packages.Package, name string) *ast.FuncDecl {
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if ok && fn.Recv == nil && fn.Name.Name == name {
+				return fn
+			}
+		}
+	}
+	return nil
+}
+
+// render writes the source code of the generated function.
+func render(pkg *types.Package, cfg Config, m *module) ([]byte, error) {
+	imports := newImports(pkg)
+	imports.add(dinotypes.Path)
+
+	regs, err := m.effective()
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	for _, reg := range regs {
+		if err := renderRegistration(&body, imports, reg); err != nil {
+			return nil, err
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by dino. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg.Name())
+	b.WriteString("import (\n")
+	specs := imports.list()
+	for i, imp := range specs {
+		// Separate the standard library from other packages, like goimports does
+		if i > 0 && isStd(specs[i-1].path) && !isStd(imp.path) {
+			b.WriteString("\n")
+		}
+		if imp.name == path.Base(imp.path) {
+			fmt.Fprintf(&b, "\t%q\n", imp.path)
+		} else {
+			fmt.Fprintf(&b, "\t%s %q\n", imp.name, imp.path)
+		}
+	}
+	b.WriteString(")\n\n")
+	fmt.Fprintf(&b, "// %s registers the same services as %s,\n", cfg.Func, cfg.Module)
+	b.WriteString("// but constructs them without reflection.\n")
+	fmt.Fprintf(&b, "func %s(c *%s.Container) error {\n", cfg.Func, imports.name(dinotypes.Path))
+	b.Write(body.Bytes())
+	b.WriteString("\treturn nil\n}\n")
+
+	return b.Bytes(), nil
+}
+
+// renderRegistration writes a statement registering a single service.
+func renderRegistration(b *bytes.Buffer, imports *imports, reg *registration) error {
+	dino := imports.name(dinotypes.Path)
+
+	svc, err := imports.typeExpr(reg.key.ty)
+	if err != nil {
+		return reg.errorf("%v", err)
+	}
+
+	if reg.kind == kindAlias {
+		target, err := imports.typeExpr(reg.target.ty)
+		if err != nil {
+			return reg.errorf("%v", err)
+		}
+		fmt.Fprintf(b, "\tif err := %s.AliasNamed[%s, %s](c, %q, %q); err != nil {\n\t\treturn err\n\t}\n",
+			dino, svc, target, reg.key.name, reg.target.name)
+		return nil
+	}
+
+	fn := "AddFactoryNamed"
+	if reg.kind == kindTransient {
+		fn = "AddTransientFactoryNamed"
+	}
+
+	impl, err := imports.typeExpr(reg.impl)
+	if err != nil {
+		return reg.errorf("%v", err)
+	}
+
+	zero := "nil"
+	if _, isStruct := reg.key.ty.Underlying().(*types.Struct); isStruct {
+		zero = svc + "{}"
+	}
+
+	fmt.Fprintf(b, "\tif err := %s.%s(c, %q, func(c *%s.Container) (%s, error) {\n", dino, fn, reg.key.name, dino, svc)
+	fmt.Fprintf(b, "\t\tsvc := &%s{}\n", impl)
+	for _, field := range reg.fields {
+		fieldTy, err := imports.typeExpr(field.key.ty)
+		if err != nil {
+			return reg.errorf("field %s: %v", field.name, err)
+		}
+		fmt.Fprintf(b, "\t\tif dep, err := %s.GetNamed[%s](c, %q); err == nil {\n", dino, fieldTy, field.key.name)
+		fmt.Fprintf(b, "\t\t\tsvc.%s = dep\n", field.name)
+		fmt.Fprintf(b, "\t\t} else if _, missing := err.(%s.BindingMissingError); !missing {\n", dino)
+		fmt.Fprintf(b, "\t\t\treturn %s, err\n", zero)
+		b.WriteString("\t\t}\n")
+	}
+
+	if zero == "nil" {
+		b.WriteString("\t\treturn svc, nil\n")
+	} else {
+		b.WriteString("\t\treturn *svc, nil\n")
+	}
+	b.WriteString("\t}); err != nil {\n\t\treturn err\n\t}\n")
+
+	for _, alias := range reg.shared {
+		target, _ := imports.typeExpr(reg.key.ty)
+		as, err := imports.typeExpr(alias.ty)
+		if err != nil {
+			return reg.errorf("%v", err)
+		}
+		fmt.Fprintf(b, "\tif err := %s.AliasNamed[%s, %s](c, %q, %q); err != nil {\n\t\treturn err\n\t}\n",
+			dino, as, target, alias.name, reg.key.name)
+	}
+
+	return nil
+}
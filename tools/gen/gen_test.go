@@ -0,0 +1,36 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateMatchesCommittedExample(t *testing.T) {
+	out := filepath.Join("testdata", "example", "register_dino.go")
+	expected, err := os.ReadFile(out)
+	assert.NoError(t, err)
+
+	src, err := Generate(Config{Dir: filepath.Join("testdata", "example"), Module: "Register", Out: out})
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(src), "generated code is out of date, run go generate")
+}
+
+func TestGenerateFailsMissingModule(t *testing.T) {
+	_, err := Generate(Config{Dir: filepath.Join("testdata", "example"), Module: "Nope"})
+	assert.ErrorContains(t, err, "module function Nope not found")
+}
+
+func TestGenerateFailsInvalidModule(t *testing.T) {
+	for dir, msg := range map[string]string{
+		"cyclic":      "cyclic dependency",
+		"missing":     "missing binding",
+		"unsupported": "not supported",
+		"notimpl":     "is not implemented by",
+	} {
+		_, err := Generate(Config{Dir: filepath.Join("testdata", dir), Module: "Register"})
+		assert.ErrorContains(t, err, msg, dir)
+	}
+}
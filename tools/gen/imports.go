@@ -0,0 +1,143 @@
+package gen
+
+import (
+	"fmt"
+	"go/types"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// importSpec is a single import of the generated file.
+type importSpec struct {
+	name string
+	path string
+}
+
+// imports keeps track of packages imported by the generated file.
+type imports struct {
+	pkg    *types.Package    // Package the code is generated for.
+	byPath map[string]string // Names of imported packages, by their paths.
+	used   map[string]bool   // Names already taken by imports.
+}
+
+func newImports(pkg *types.Package) *imports {
+	return &imports{
+		pkg:    pkg,
+		byPath: make(map[string]string),
+		used:   make(map[string]bool),
+	}
+}
+
+// add imports a package by its path, assuming its name is the last element of the path,
+// and returns the name it can be referred to by.
+func (i *imports) add(importPath string) string {
+	return i.addNamed(importPath, path.Base(importPath))
+}
+
+// addNamed imports a package with a known name
+// and returns the name it can be referred to by.
+func (i *imports) addNamed(importPath string, name string) string {
+	if existing, ok := i.byPath[importPath]; ok {
+		return existing
+	}
+
+	unique := name
+	for n := 2; i.used[unique]; n++ {
+		unique = name + strconv.Itoa(n)
+	}
+
+	i.byPath[importPath] = unique
+	i.used[unique] = true
+	return unique
+}
+
+// name returns the name an already imported package can be referred to by.
+func (i *imports) name(importPath string) string {
+	return i.byPath[importPath]
+}
+
+// list returns all the imports, sorted by their paths.
+func (i *imports) list() []importSpec {
+	specs := make([]importSpec, 0, len(i.byPath))
+	for p, name := range i.byPath {
+		specs = append(specs, importSpec{name: name, path: p})
+	}
+	sort.Slice(specs, func(a, b int) bool {
+		return specs[a].path < specs[b].path
+	})
+	return specs
+}
+
+// isStd checks whether an import path belongs to the standard library.
+func isStd(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
+
+// typeExpr returns an expression referring to a type from the generated file,
+// importing packages as necessary.
+func (i *imports) typeExpr(t types.Type) (string, error) {
+	if err := i.checkAccessible(t); err != nil {
+		return "", err
+	}
+
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == i.pkg {
+			return ""
+		}
+		return i.addNamed(p.Path(), p.Name())
+	}), nil
+}
+
+// checkAccessible checks whether a type can be referred to from the generated file.
+func (i *imports) checkAccessible(t types.Type) error {
+	switch t := t.(type) {
+	case *types.Named:
+		obj := t.Obj()
+		if obj.Pkg() != nil {
+			if obj.Pkg() != i.pkg && !obj.Exported() {
+				return fmt.Errorf("type %s is not exported from package %s", obj.Name(), obj.Pkg().Path())
+			} else if obj.Parent() != obj.Pkg().Scope() {
+				return fmt.Errorf("type %s is not declared at package level", obj.Name())
+			}
+		}
+
+		args := t.TypeArgs()
+		for n := 0; args != nil && n < args.Len(); n++ {
+			if err := i.checkAccessible(args.At(n)); err != nil {
+				return err
+			}
+		}
+	case *types.Pointer:
+		return i.checkAccessible(t.Elem())
+	case *types.Slice:
+		return i.checkAccessible(t.Elem())
+	case *types.Array:
+		return i.checkAccessible(t.Elem())
+	case *types.Chan:
+		return i.checkAccessible(t.Elem())
+	case *types.Map:
+		if err := i.checkAccessible(t.Key()); err != nil {
+			return err
+		}
+		return i.checkAccessible(t.Elem())
+	case *types.Signature:
+		for _, tuple := range []*types.Tuple{t.Params(), t.Results()} {
+			for n := 0; n < tuple.Len(); n++ {
+				if err := i.checkAccessible(tuple.At(n).Type()); err != nil {
+					return err
+				}
+			}
+		}
+	case *types.Struct:
+		for n := 0; n < t.NumFields(); n++ {
+			if err := i.checkAccessible(t.Field(n).Type()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
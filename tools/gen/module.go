@@ -0,0 +1,380 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/frixuu/dino/tools/internal/dinotypes"
+	"golang.org/x/tools/go/packages"
+)
+
+// kind describes how a registered service gets provided.
+type kind int
+
+const (
+	kindSingleton kind = iota
+	kindTransient
+	kindAlias
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindSingleton:
+		return "singleton"
+	case kindTransient:
+		return "transient"
+	case kindAlias:
+		return "alias"
+	default:
+		return "???"
+	}
+}
+
+// key identifies a binding, just like a type-name pair in a runtime container.
+type key struct {
+	ty   types.Type
+	name string
+}
+
+// id returns a string that uniquely identifies the key.
+func (k key) id() string {
+	return types.TypeString(k.ty, nil) + "\x00" + k.name
+}
+
+func (k key) String() string {
+	s := types.TypeString(k.ty, func(p *types.Package) string { return p.Name() })
+	if k.name != "" {
+		s += " (named:" + k.name + ")"
+	}
+	return s
+}
+
+// field describes a field of an implementation struct that gets injected.
+type field struct {
+	name string
+	key  key
+}
+
+// registration describes a single registration made in a module.
+type registration struct {
+	pos    token.Position
+	key    key
+	kind   kind
+	impl   types.Type // Struct type to construct, for singletons and transients.
+	fields []field    // Injectable fields of the implementation.
+	target key        // Target of an alias.
+	shared []key      // Other keys, under which the same singleton is available.
+}
+
+func (r *registration) errorf(format string, args ...any) error {
+	return fmt.Errorf("%s: "+format, append([]any{r.pos}, args...)...)
+}
+
+// store records a registration being stored under a key.
+type store struct {
+	key    key
+	reg    *registration
+	shared bool // Whether the key is one of the shared keys of the registration.
+}
+
+// module describes all the registrations made in a module function.
+type module struct {
+	regs   []*registration
+	stores []store
+}
+
+// unsupported lists registration functions that cannot be generated,
+// because they depend on values only available at runtime.
+var unsupported = map[string]bool{
+	"AddInstance":              true,
+	"AddInstanceNamed":         true,
+	"AddFactory":               true,
+	"AddFactoryNamed":          true,
+	"AddTransientFactory":      true,
+	"AddTransientFactoryNamed": true,
+	"AddIf":                    true,
+}
+
+// parseModule finds all the calls to Dino registration functions in a module.
+func parseModule(pkg *packages.Package, decl *ast.FuncDecl) (*module, error) {
+	m := &module{}
+	if decl.Body == nil {
+		return m, nil
+	}
+
+	var err error
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if err != nil {
+			return false
+		}
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		fn, typeArgs := dinotypes.CalledFunc(pkg.TypesInfo, call)
+		if fn == nil {
+			return true
+		}
+
+		err = m.addCall(pkg, call, strings.TrimPrefix(fn.Name(), "Must"), typeArgs)
+		return false
+	})
+
+	return m, err
+}
+
+// addCall records a registration made by a call to a Dino function.
+func (m *module) addCall(pkg *packages.Package, call *ast.CallExpr, fn string, typeArgs []types.Type) error {
+	reg := &registration{pos: pkg.Fset.Position(call.Pos())}
+
+	if unsupported[fn] {
+		return reg.errorf("%s is not supported in generated modules, register the service outside of the module", fn)
+	}
+
+	var err error
+	switch fn {
+	case "Add", "AddNamed", "AddTransient", "AddTransientNamed":
+		reg.kind = kindSingleton
+		if strings.HasPrefix(fn, "AddTransient") {
+			reg.kind = kindTransient
+		}
+
+		reg.key.ty, reg.impl = typeArgs[0], typeArgs[1]
+		if strings.HasSuffix(fn, "Named") {
+			if reg.key.name, err = stringArg(pkg.TypesInfo, reg, call.Args[1]); err != nil {
+				return err
+			}
+		}
+
+		if err := dinotypes.CheckImplType(reg.key.ty, reg.impl); err != nil {
+			return reg.errorf("%v", err)
+		}
+
+	case "AddAs":
+		reg.kind = kindSingleton
+		reg.impl = typeArgs[0]
+		reg.key.ty = types.NewPointer(reg.impl)
+		if err := dinotypes.CheckImplType(reg.key.ty, reg.impl); err != nil {
+			return reg.errorf("%v", err)
+		}
+
+		if call.Ellipsis.IsValid() {
+			return reg.errorf("AddAs options must be listed explicitly in generated modules")
+		}
+
+		for _, arg := range call.Args[1:] {
+			as, err := asOption(pkg.TypesInfo, reg, arg)
+			if err != nil {
+				return err
+			}
+			if err := dinotypes.CheckAsOption(as.ty, reg.impl); err != nil {
+				return reg.errorf("%v", err)
+			}
+			if as.id() != reg.key.id() {
+				reg.shared = append(reg.shared, as)
+			}
+		}
+
+	case "Alias", "AliasNamed":
+		reg.kind = kindAlias
+		reg.key.ty, reg.target.ty = typeArgs[0], typeArgs[1]
+		if fn == "AliasNamed" {
+			if reg.key.name, err = stringArg(pkg.TypesInfo, reg, call.Args[1]); err != nil {
+				return err
+			}
+			if reg.target.name, err = stringArg(pkg.TypesInfo, reg, call.Args[2]); err != nil {
+				return err
+			}
+		}
+
+		if err := dinotypes.CheckAlias(reg.key.ty, reg.target.ty); err != nil {
+			return reg.errorf("%v", err)
+		}
+
+	default:
+		// Not a registration
+		return nil
+	}
+
+	if reg.impl != nil {
+		for _, f := range dinotypes.InjectableFields(reg.impl) {
+			reg.fields = append(reg.fields, field{
+				name: f.Var.Name(),
+				key:  key{ty: f.Var.Type(), name: f.Name},
+			})
+		}
+	}
+
+	m.regs = append(m.regs, reg)
+	m.stores = append(m.stores, store{key: reg.key, reg: reg})
+	for _, k := range reg.shared {
+		m.stores = append(m.stores, store{key: k, reg: reg, shared: true})
+	}
+
+	return nil
+}
+
+// stringArg returns the value of a constant string argument.
+func stringArg(info *types.Info, reg *registration, arg ast.Expr) (string, error) {
+	tv, ok := info.Types[arg]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", reg.errorf("names must be constant strings in generated modules")
+	}
+	return constant.StringVal(tv.Value), nil
+}
+
+// asOption returns the key described by a call to dino.As or dino.AsNamed.
+func asOption(info *types.Info, reg *registration, arg ast.Expr) (key, error) {
+	call, ok := ast.Unparen(arg).(*ast.CallExpr)
+	if !ok {
+		return key{}, reg.errorf("AddAs options must be calls to As or AsNamed in generated modules")
+	}
+
+	fn, typeArgs := dinotypes.CalledFunc(info, call)
+	if fn == nil || (fn.Name() != "As" && fn.Name() != "AsNamed") {
+		return key{}, reg.errorf("AddAs options must be calls to As or AsNamed in generated modules")
+	}
+
+	k := key{ty: typeArgs[0]}
+	if fn.Name() == "AsNamed" {
+		name, err := stringArg(info, reg, call.Args[0])
+		if err != nil {
+			return key{}, err
+		}
+		k.name = name
+	}
+
+	return k, nil
+}
+
+// final returns the registration stored last for each key.
+func (m *module) final() map[string]store {
+	final := make(map[string]store)
+	for _, s := range m.stores {
+		final[s.key.id()] = s
+	}
+	return final
+}
+
+// effective returns registrations that have not been replaced by later ones,
+// along with only those of their shared keys that have not been replaced.
+func (m *module) effective() ([]*registration, error) {
+	final := m.final()
+	regs := make([]*registration, 0, len(m.regs))
+	for _, reg := range m.regs {
+		var shared []key
+		for _, k := range reg.shared {
+			if s := final[k.id()]; s.reg == reg && s.shared {
+				shared = append(shared, k)
+			}
+		}
+
+		if s := final[reg.key.id()]; s.reg != reg || s.shared {
+			if len(shared) > 0 {
+				return nil, reg.errorf("%s is registered again later, so its instance cannot be shared", reg.key)
+			}
+			continue
+		}
+
+		r := *reg
+		r.shared = shared
+		regs = append(regs, &r)
+	}
+	return regs, nil
+}
+
+// dependencies returns keys of services a registration might request from the container.
+func (r *registration) dependencies() []key {
+	if r.kind == kindAlias {
+		return []key{r.target}
+	}
+
+	keys := make([]key, len(r.fields))
+	for i, f := range r.fields {
+		keys[i] = f.key
+	}
+	return keys
+}
+
+// link is a single step in a chain of dependencies.
+type link struct {
+	key key
+	reg *registration
+}
+
+// validate checks whether all the aliases point to registered services and that there are no cycles.
+//
+// Unlike the runtime container, generated code cannot construct singletons depending on themselves,
+// so every cycle is reported.
+func (m *module) validate() error {
+	final := m.final()
+	for _, reg := range m.regs {
+		if s := final[reg.key.id()]; s.reg != reg {
+			continue
+		}
+
+		if reg.kind == kindAlias {
+			if _, ok := final[reg.target.id()]; !ok {
+				return reg.errorf("alias %s points to a missing binding: %s", reg.key, describeMissing(reg.target))
+			}
+		}
+
+		chain := []link{{key: reg.key, reg: reg}}
+		if cycle := findCycle(final, reg, chain, make(map[*registration]bool)); cycle != nil {
+			return reg.errorf("cannot satisfy cyclic dependency: %s", formatChain(cycle))
+		}
+	}
+
+	return nil
+}
+
+// findCycle looks for a chain of dependencies leading from the last registration in the chain
+// back to the start registration.
+func findCycle(final map[string]store, start *registration, chain []link, visited map[*registration]bool) []link {
+	current := chain[len(chain)-1].reg
+	if visited[current] {
+		return nil
+	}
+	visited[current] = true
+
+	for _, dep := range current.dependencies() {
+		s, ok := final[dep.id()]
+		if !ok {
+			continue
+		}
+
+		next := append(chain[:len(chain):len(chain)], link{key: dep, reg: s.reg})
+		if s.reg == start {
+			return next
+		}
+		if cycle := findCycle(final, start, next, visited); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+// formatChain describes a chain of dependencies the same way the runtime container does.
+func formatChain(chain []link) string {
+	parts := make([]string, len(chain))
+	for i, l := range chain {
+		parts[i] = l.key.String() + " (" + l.reg.kind.String() + ")"
+	}
+	return strings.Join(parts, " ---> ")
+}
+
+// describeMissing describes a missing binding the same way the runtime container does.
+func describeMissing(k key) string {
+	s := "container did not have any info about type " + key{ty: k.ty}.String()
+	if k.name == "" {
+		return s + " in global namespace"
+	}
+	return s + " in namespace \"" + k.name + "\""
+}
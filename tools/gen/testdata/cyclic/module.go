@@ -0,0 +1,11 @@
+package cyclic
+
+import "github.com/frixuu/dino"
+
+type A struct{ B *B }
+type B struct{ A *A }
+
+func Register(c *dino.Container) error {
+	dino.MustAdd[*A, A](c)
+	return dino.Add[*B, B](c)
+}
@@ -0,0 +1,42 @@
+package example
+
+import (
+	"testing"
+
+	"github.com/frixuu/dino"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratedModuleMatchesRuntime(t *testing.T) {
+	for name, register := range map[string]func(c *dino.Container) error{
+		"runtime":   Register,
+		"generated": RegisterGenerated,
+	} {
+		for _, seal := range []bool{false, true} {
+			c := &dino.Container{}
+			dino.MustAddInstanceNamed[string](c, "prefix", "my-")
+			logged := ""
+			dino.MustAddInstance[func(string)](c, func(s string) { logged = s })
+			assert.NoError(t, register(c), name)
+			if seal {
+				assert.NoError(t, c.Seal(), name)
+			}
+
+			ctrl, err := dino.Get[*Controller](c)
+			assert.NoError(t, err, name)
+			store := dino.MustGet[*Store](c)
+			assert.Same(t, store, ctrl.Reader, name)
+			assert.Same(t, store, ctrl.Writer, name)
+			assert.Same(t, store, ctrl.Primary, name)
+			assert.Nil(t, ctrl.private, name)
+			assert.Equal(t, "my-cache", ctrl.Reader.Read(), name)
+
+			ctrl.Logger("hello")
+			assert.Equal(t, "hello", logged, name)
+
+			other := dino.MustGet[*Controller](c)
+			assert.NotSame(t, ctrl, other, name)
+			assert.Same(t, ctrl.Primary, other.Primary, name)
+		}
+	}
+}
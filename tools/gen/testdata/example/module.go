@@ -0,0 +1,65 @@
+// Package example contains a Dino module used to test the generator.
+package example
+
+import (
+	"github.com/frixuu/dino"
+)
+
+//go:generate go run github.com/frixuu/dino/tools/cmd/dino -module Register
+
+type (
+	Cache interface {
+		Lookup(id int) string
+	}
+	Reader interface {
+		Read() string
+	}
+	Writer interface {
+		Write(s string)
+	}
+)
+
+type CacheImpl struct {
+	Prefix string `dino:"named:prefix"`
+}
+
+func (c *CacheImpl) Lookup(id int) string { return c.Prefix + "cache" }
+
+type Store struct {
+	Cache  Cache
+	Writes []string
+}
+
+func (s *Store) Read() string { return s.Cache.Lookup(0) }
+
+func (s *Store) Write(str string) { s.Writes = append(s.Writes, str) }
+
+type Config struct {
+	Env string
+}
+
+type Controller struct {
+	Reader  Reader
+	Writer  Writer
+	Primary *Store `dino:"named:primary"`
+	Config  Config
+	Logger  func(string) `dino:""`
+	private *Store
+}
+
+// Register registers all the services of the example.
+func Register(c *dino.Container) error {
+	if err := dino.Add[Cache, CacheImpl](c); err != nil {
+		return err
+	}
+	if err := dino.AddAs[Store](c, dino.As[Reader](), dino.As[Writer]()); err != nil {
+		return err
+	}
+	if err := dino.AliasNamed[*Store, *Store](c, "primary", ""); err != nil {
+		return err
+	}
+	if err := dino.AddTransient[Config, Config](c); err != nil {
+		return err
+	}
+	return dino.AddTransient[*Controller, Controller](c)
+}
@@ -0,0 +1,81 @@
+// Code generated by dino. DO NOT EDIT.
+
+package example
+
+import (
+	"github.com/frixuu/dino"
+)
+
+// RegisterGenerated registers the same services as Register,
+// but constructs them without reflection.
+func RegisterGenerated(c *dino.Container) error {
+	if err := dino.AddFactoryNamed(c, "", func(c *dino.Container) (Cache, error) {
+		svc := &CacheImpl{}
+		if dep, err := dino.GetNamed[string](c, "prefix"); err == nil {
+			svc.Prefix = dep
+		} else if _, missing := err.(dino.BindingMissingError); !missing {
+			return nil, err
+		}
+		return svc, nil
+	}); err != nil {
+		return err
+	}
+	if err := dino.AddFactoryNamed(c, "", func(c *dino.Container) (*Store, error) {
+		svc := &Store{}
+		if dep, err := dino.GetNamed[Cache](c, ""); err == nil {
+			svc.Cache = dep
+		} else if _, missing := err.(dino.BindingMissingError); !missing {
+			return nil, err
+		}
+		return svc, nil
+	}); err != nil {
+		return err
+	}
+	if err := dino.AliasNamed[Reader, *Store](c, "", ""); err != nil {
+		return err
+	}
+	if err := dino.AliasNamed[Writer, *Store](c, "", ""); err != nil {
+		return err
+	}
+	if err := dino.AliasNamed[*Store, *Store](c, "primary", ""); err != nil {
+		return err
+	}
+	if err := dino.AddTransientFactoryNamed(c, "", func(c *dino.Container) (Config, error) {
+		svc := &Config{}
+		return *svc, nil
+	}); err != nil {
+		return err
+	}
+	if err := dino.AddTransientFactoryNamed(c, "", func(c *dino.Container) (*Controller, error) {
+		svc := &Controller{}
+		if dep, err := dino.GetNamed[Reader](c, ""); err == nil {
+			svc.Reader = dep
+		} else if _, missing := err.(dino.BindingMissingError); !missing {
+			return nil, err
+		}
+		if dep, err := dino.GetNamed[Writer](c, ""); err == nil {
+			svc.Writer = dep
+		} else if _, missing := err.(dino.BindingMissingError); !missing {
+			return nil, err
+		}
+		if dep, err := dino.GetNamed[*Store](c, "primary"); err == nil {
+			svc.Primary = dep
+		} else if _, missing := err.(dino.BindingMissingError); !missing {
+			return nil, err
+		}
+		if dep, err := dino.GetNamed[Config](c, ""); err == nil {
+			svc.Config = dep
+		} else if _, missing := err.(dino.BindingMissingError); !missing {
+			return nil, err
+		}
+		if dep, err := dino.GetNamed[func(string)](c, ""); err == nil {
+			svc.Logger = dep
+		} else if _, missing := err.(dino.BindingMissingError); !missing {
+			return nil, err
+		}
+		return svc, nil
+	}); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+//go:build tools
+
+// The generator is a dependency of the module, so that go:generate runs the version listed in go.mod.
+package example
+
+import _ "github.com/frixuu/dino/tools/cmd/dino"
@@ -0,0 +1,9 @@
+package missing
+
+import "github.com/frixuu/dino"
+
+type Service struct{}
+
+func Register(c *dino.Container) error {
+	return dino.AliasNamed[*Service, *Service](c, "primary", "nowhere")
+}
@@ -0,0 +1,10 @@
+package notimpl
+
+import "github.com/frixuu/dino"
+
+type Service interface{ Serve() }
+type Impl struct{}
+
+func Register(c *dino.Container) error {
+	return dino.Add[Service, Impl](c)
+}
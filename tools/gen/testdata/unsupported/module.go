@@ -0,0 +1,9 @@
+package unsupported
+
+import "github.com/frixuu/dino"
+
+type Service struct{}
+
+func Register(c *dino.Container) error {
+	return dino.AddInstance[*Service](c, &Service{})
+}
@@ -0,0 +1,206 @@
+// Package dinotypes mirrors the checks of the Dino container on static type information,
+// so that tools can report the same mistakes without running the code.
+package dinotypes
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+// Path is the import path of the Dino package.
+const Path = "github.com/frixuu/dino"
+
+// TagKeys lists the keys the container understands in dino struct tags.
+var TagKeys = map[string]bool{
+	"named":    true,
+	"optional": true,
+}
+
+// CalledFunc returns the Dino function called by the expression, if any, along with its type arguments.
+func CalledFunc(info *types.Info, call *ast.CallExpr) (*types.Func, []types.Type) {
+	expr := call.Fun
+	switch e := expr.(type) {
+	case *ast.IndexExpr:
+		expr = e.X
+	case *ast.IndexListExpr:
+		expr = e.X
+	}
+
+	var ident *ast.Ident
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	case *ast.Ident:
+		ident = e
+	default:
+		return nil, nil
+	}
+
+	fn, ok := info.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != Path {
+		return nil, nil
+	}
+
+	instance := info.Instances[ident]
+	typeArgs := make([]types.Type, 0)
+	if instance.TypeArgs != nil {
+		for i := 0; i < instance.TypeArgs.Len(); i++ {
+			typeArgs = append(typeArgs, instance.TypeArgs.At(i))
+		}
+	}
+
+	return fn, typeArgs
+}
+
+// TypeString describes a type the same way the container's errors do.
+func TypeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string { return p.Name() })
+}
+
+// CheckImplType checks whether a service of type t can be constructed from a struct of type impl.
+//
+// It mirrors the checks of the runtime container.
+func CheckImplType(t types.Type, impl types.Type) error {
+	if _, ok := impl.Underlying().(*types.Struct); !ok {
+		return fmt.Errorf("implementation type %s is not a struct", TypeString(impl))
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Interface:
+		if !types.Implements(types.NewPointer(impl), u) {
+			return fmt.Errorf("interface %s is not implemented by type %s", TypeString(t), TypeString(impl))
+		}
+	case *types.Pointer:
+		if _, ok := u.Elem().Underlying().(*types.Struct); !ok {
+			return fmt.Errorf("type %s is not a valid service type", TypeString(t))
+		} else if !types.Identical(u.Elem(), impl) {
+			return fmt.Errorf("service pointer type %s does not match impl type %s", TypeString(u.Elem()), TypeString(impl))
+		}
+	case *types.Struct:
+		if !types.Identical(t, impl) {
+			return fmt.Errorf("value of type %s cannot be registered as service type %s", TypeString(impl), TypeString(t))
+		}
+	default:
+		return fmt.Errorf("type %s is not a valid service type", TypeString(t))
+	}
+
+	return nil
+}
+
+// CheckAsOption checks whether a singleton constructed from a struct of type impl
+// can be shared as a service of type t.
+func CheckAsOption(t types.Type, impl types.Type) error {
+	if _, isStruct := t.Underlying().(*types.Struct); isStruct {
+		// A struct value cannot share its instance with the pointer
+		return fmt.Errorf("type %s is not a valid service type", TypeString(t))
+	}
+	return CheckImplType(t, impl)
+}
+
+// CheckAlias checks whether a service of type from can be provided as type to.
+func CheckAlias(to types.Type, from types.Type) error {
+	if !IsValidServiceType(to) {
+		return fmt.Errorf("type %s is not a valid service type", TypeString(to))
+	}
+
+	if iface, ok := to.Underlying().(*types.Interface); ok {
+		if !types.Implements(from, iface) {
+			return fmt.Errorf("interface %s is not implemented by type %s", TypeString(to), TypeString(from))
+		}
+	} else if !types.AssignableTo(from, to) {
+		return fmt.Errorf("value of type %s cannot be registered as service type %s", TypeString(from), TypeString(to))
+	}
+
+	return nil
+}
+
+// IsValidServiceType checks whether values of a type can be registered in a container.
+func IsValidServiceType(t types.Type) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return u.Kind() != types.UnsafePointer && u.Kind() != types.Invalid
+	case *types.Pointer:
+		_, isIf := u.Elem().Underlying().(*types.Interface)
+		return !isIf
+	default:
+		return true
+	}
+}
+
+// TagOption is a single key-value pair of a dino struct tag.
+type TagOption struct {
+	Key   string
+	Value string
+}
+
+// ParseTag returns the options of the dino key of a struct tag, the same way the container reads them.
+func ParseTag(tag string) ([]TagOption, bool) {
+	value, ok := reflect.StructTag(tag).Lookup("dino")
+	if !ok {
+		return nil, false
+	}
+
+	pairs := strings.Split(value, ";")
+	opts := make([]TagOption, len(pairs))
+	for i, pair := range pairs {
+		k, v, _ := strings.Cut(pair, ":")
+		opts[i] = TagOption{Key: k, Value: v}
+	}
+	return opts, true
+}
+
+// ServiceName returns the namespace a field should be injected from, based on its tag.
+func ServiceName(tag string) string {
+	opts, _ := ParseTag(tag)
+	name := ""
+	for _, opt := range opts {
+		if opt.Key == "named" {
+			name = opt.Value
+		}
+	}
+	return name
+}
+
+// Field is an exported field of an implementation struct, that the container would try to inject.
+type Field struct {
+	Var  *types.Var
+	Name string // Namespace the field gets injected from.
+}
+
+// InjectableFields returns exported fields of a struct, that the runtime container would try to inject.
+func InjectableFields(impl types.Type) []Field {
+	st := impl.Underlying().(*types.Struct)
+
+	fields := make([]Field, 0, st.NumFields())
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if !f.Exported() || !IsValidServiceType(f.Type()) || IsMarker(f) {
+			continue
+		}
+
+		// Values other than interfaces, pointers and structs only get injected when tagged.
+		// Untagged structs only get injected if registered, which the container checks at runtime.
+		switch f.Type().Underlying().(type) {
+		case *types.Interface, *types.Pointer, *types.Struct:
+		default:
+			if _, tagged := ParseTag(st.Tag(i)); !tagged {
+				continue
+			}
+		}
+		fields = append(fields, Field{Var: f, Name: ServiceName(st.Tag(i))})
+	}
+	return fields
+}
+
+// IsMarker checks whether a field is the dino.In or dino.Out marker embedded in a parameter or bundle struct.
+func IsMarker(f *types.Var) bool {
+	named, ok := f.Type().(*types.Named)
+	if !ok || !f.Embedded() {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == Path && (obj.Name() == "In" || obj.Name() == "Out")
+}